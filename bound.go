@@ -0,0 +1,70 @@
+package graftel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// BoundCounter é um Counter com atributos fixos, pré-computados em Bind.
+// Cada medição reutiliza o mesmo attribute.Set, sem alocar nem reordenar atributos,
+// o que o torna indicado para caminhos quentes com um conjunto conhecido de séries.
+type BoundCounter struct {
+	counter otelmetric.Int64Counter
+	opts    []otelmetric.AddOption
+}
+
+// Bind retorna um BoundCounter com attrs pré-computados.
+// O limite de cardinalidade é aplicado uma única vez, na criação, e tags do
+// contexto (WithContextTags) não são anexadas às medições do contador vinculado.
+//
+//	paid := orders.Bind(attribute.String("status", "paid"))
+//	paid.Increment(ctx)
+func (c *Counter) Bind(attrs ...attribute.KeyValue) *BoundCounter {
+	set := c.cardinality.admit(attrs)
+	return &BoundCounter{
+		counter: c.counter,
+		opts:    []otelmetric.AddOption{otelmetric.WithAttributeSet(set)},
+	}
+}
+
+// Add incrementa o contador pelo valor especificado.
+func (b *BoundCounter) Add(ctx context.Context, value int64) {
+	b.counter.Add(ctx, value, b.opts...)
+}
+
+// Increment incrementa o contador em 1.
+func (b *BoundCounter) Increment(ctx context.Context) {
+	b.counter.Add(ctx, 1, b.opts...)
+}
+
+// BoundHistogram é um Histogram com atributos fixos, pré-computados em Bind.
+type BoundHistogram struct {
+	histogram otelmetric.Float64Histogram
+	unit      DurationUnit
+	opts      []otelmetric.RecordOption
+}
+
+// Bind retorna um BoundHistogram com attrs pré-computados.
+// Assim como em Counter.Bind, o limite de cardinalidade é aplicado na criação e tags
+// do contexto não são anexadas.
+func (h *Histogram) Bind(attrs ...attribute.KeyValue) *BoundHistogram {
+	set := h.cardinality.admit(attrs)
+	return &BoundHistogram{
+		histogram: h.histogram,
+		unit:      h.unit,
+		opts:      []otelmetric.RecordOption{otelmetric.WithAttributeSet(set)},
+	}
+}
+
+// Record registra um valor no histograma.
+func (b *BoundHistogram) Record(ctx context.Context, value float64) {
+	b.histogram.Record(ctx, value, b.opts...)
+}
+
+// RecordDuration registra uma duração na unidade do histograma.
+func (b *BoundHistogram) RecordDuration(ctx context.Context, duration time.Duration) {
+	b.histogram.Record(ctx, b.unit.convert(duration), b.opts...)
+}
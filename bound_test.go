@@ -0,0 +1,145 @@
+package graftel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCounter_BindSharesSeriesWithAdd(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	counter, err := helper.NewCounter("orders_total", "Pedidos")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	paid := counter.Bind(attribute.String("status", "paid"), attribute.String("method", "pix"))
+	paid.Increment(ctx)
+	paid.Add(ctx, 2)
+	// A ordem dos atributos não importa: a série é a mesma
+	counter.Add(ctx, 4, attribute.String("method", "pix"), attribute.String("status", "paid"))
+
+	sum := collectMetrics(t, reader)["orders_total"].Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 1 {
+		t.Fatalf("esperada 1 série, obtidas %d", len(sum.DataPoints))
+	}
+	if sum.DataPoints[0].Value != 7 {
+		t.Errorf("orders_total = %d, esperado 7", sum.DataPoints[0].Value)
+	}
+}
+
+func TestHistogram_BindRecordDuration(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	histogram, err := NewDurationHistogram(helper, "query_duration", "Consultas", DurationMilliseconds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bound := histogram.Bind(attribute.String("table", "users"))
+	bound.RecordDuration(context.Background(), 250*time.Millisecond)
+	bound.Record(context.Background(), 50)
+
+	data := collectMetrics(t, reader)["query_duration"].Data.(metricdata.Histogram[float64])
+	dp := data.DataPoints[0]
+	if dp.Count != 2 || dp.Sum != 300 {
+		t.Errorf("count = %d, sum = %v; esperado 2 e 300", dp.Count, dp.Sum)
+	}
+	if v, _ := dp.Attributes.Value("table"); v.AsString() != "users" {
+		t.Errorf("atributo table = %q, esperado users", v.AsString())
+	}
+}
+
+func TestCounter_BindRespectsCardinalityLimit(t *testing.T) {
+	captureErrors(t)
+	reader := metric.NewManualReader()
+	helper := &metricsHelper{
+		meter:       metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"),
+		cardinality: newCardinalityLimiter(2, nil),
+	}
+	counter, err := helper.NewCounter("jobs_total", "Jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	counter.Bind(attribute.String("queue", "a")).Increment(ctx)
+	counter.Bind(attribute.String("queue", "b")).Increment(ctx)
+
+	sum := collectMetrics(t, reader)["jobs_total"].Data.(metricdata.Sum[int64])
+	for _, dp := range sum.DataPoints {
+		if v, _ := dp.Attributes.Value("queue"); v.AsString() == "b" {
+			t.Error("série acima do limite deveria ir para o overflow")
+		}
+	}
+}
+
+func TestBound_ZeroAllocations(t *testing.T) {
+	helper, _ := newTestMetricsHelper(t)
+	counter, _ := helper.NewCounter("requests_total", "Requisições")
+	histogram, _ := helper.NewHistogram("latency", "Latência")
+
+	ctx := context.Background()
+	attrs := []attribute.KeyValue{attribute.String("route", "/users"), attribute.Int("status", 200)}
+	boundCounter := counter.Bind(attrs...)
+	boundHistogram := histogram.Bind(attrs...)
+
+	if allocs := testing.AllocsPerRun(100, func() { boundCounter.Increment(ctx) }); allocs != 0 {
+		t.Errorf("BoundCounter.Increment alocou %v vezes por chamada", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { boundHistogram.Record(ctx, 0.1) }); allocs != 0 {
+		t.Errorf("BoundHistogram.Record alocou %v vezes por chamada", allocs)
+	}
+}
+
+func BenchmarkCounter_Add(b *testing.B) {
+	helper, _ := newTestMetricsHelper(b)
+	counter, _ := helper.NewCounter("requests_total", "Requisições")
+	ctx := context.Background()
+	route, status := attribute.String("route", "/users"), attribute.Int("status", 200)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		counter.Add(ctx, 1, route, status)
+	}
+}
+
+func BenchmarkBoundCounter_Add(b *testing.B) {
+	helper, _ := newTestMetricsHelper(b)
+	counter, _ := helper.NewCounter("requests_total", "Requisições")
+	bound := counter.Bind(attribute.String("route", "/users"), attribute.Int("status", 200))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bound.Add(ctx, 1)
+	}
+}
+
+func BenchmarkHistogram_Record(b *testing.B) {
+	helper, _ := newTestMetricsHelper(b)
+	histogram, _ := helper.NewHistogram("latency", "Latência")
+	ctx := context.Background()
+	route, status := attribute.String("route", "/users"), attribute.Int("status", 200)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		histogram.Record(ctx, 0.1, route, status)
+	}
+}
+
+func BenchmarkBoundHistogram_Record(b *testing.B) {
+	helper, _ := newTestMetricsHelper(b)
+	histogram, _ := helper.NewHistogram("latency", "Latência")
+	bound := histogram.Bind(attribute.String("route", "/users"), attribute.Int("status", 200))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bound.Record(ctx, 0.1)
+	}
+}
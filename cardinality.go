@@ -0,0 +1,252 @@
+package graftel
+
+import (
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OverflowAttribute é o atributo do série que recebe as medições excedentes
+// quando um instrumento atinge seu limite de cardinalidade.
+var OverflowAttribute = attribute.Bool("otel.metric.overflow", true)
+
+// maxTrackedValuesPerKey limita a memória usada para medir os valores distintos de cada chave.
+const maxTrackedValuesPerKey = 10000
+
+// overflowSet é o conjunto de atributos da série de overflow, compartilhado por todos os instrumentos.
+var overflowSet = attribute.NewSet(OverflowAttribute)
+
+// CardinalityReporter é implementado pelo Client retornado por NewClient. Use uma
+// type assertion para obter o relatório sem depender da interface Client:
+//
+//	if reporter, ok := client.(graftel.CardinalityReporter); ok {
+//		for _, r := range reporter.CardinalityReport() { ... }
+//	}
+type CardinalityReporter interface {
+	// CardinalityReport retorna a cardinalidade atual dos instrumentos com limite configurado,
+	// incluindo as chaves de atributo que mais contribuem para novas séries.
+	CardinalityReport() []CardinalityReport
+}
+
+// CardinalityReport descreve a cardinalidade de um instrumento com limite configurado.
+type CardinalityReport struct {
+	// Scope é o nome do MetricsHelper (escopo de instrumentação) que criou o instrumento.
+	Scope string
+
+	// Instrument é o nome do instrumento.
+	Instrument string
+
+	// Limit é o número máximo de conjuntos de atributos distintos.
+	Limit int
+
+	// Series é o número de conjuntos de atributos distintos aceitos.
+	Series int
+
+	// OverflowMeasurements é o número de medições direcionadas à série de overflow.
+	OverflowMeasurements int64
+
+	// TopKeys são as chaves de atributo com mais valores distintos, em ordem decrescente.
+	TopKeys []AttributeKeyCardinality
+}
+
+// Overflowed indica se o instrumento atingiu o limite de cardinalidade.
+func (r CardinalityReport) Overflowed() bool {
+	return r.OverflowMeasurements > 0
+}
+
+// AttributeKeyCardinality é o número de valores distintos observados para uma chave de atributo.
+type AttributeKeyCardinality struct {
+	Key            string
+	DistinctValues int
+}
+
+// cardinalityLimiter aplica limites de cardinalidade por instrumento. Os limites são
+// configurados por nome, mas o estado é mantido por (escopo, nome), já que instrumentos
+// homônimos em escopos diferentes são streams distintos.
+type cardinalityLimiter struct {
+	defaultLimit int
+	limits       map[string]int
+
+	mu          sync.Mutex
+	instruments map[instrumentID]*instrumentCardinality
+}
+
+// instrumentID identifica um instrumento pelo escopo e nome.
+type instrumentID struct {
+	scope string
+	name  string
+}
+
+// newCardinalityLimiter cria um limitador. Retorna nil quando nenhum limite está configurado.
+func newCardinalityLimiter(defaultLimit int, limits map[string]int) *cardinalityLimiter {
+	if defaultLimit <= 0 && len(limits) == 0 {
+		return nil
+	}
+	return &cardinalityLimiter{
+		defaultLimit: defaultLimit,
+		limits:       limits,
+		instruments:  make(map[instrumentID]*instrumentCardinality),
+	}
+}
+
+// forInstrument retorna o estado de cardinalidade do instrumento, compartilhado entre
+// todos os helpers do mesmo escopo no Client. Retorna nil quando o instrumento não tem limite.
+func (l *cardinalityLimiter) forInstrument(scope, name string) *instrumentCardinality {
+	if l == nil {
+		return nil
+	}
+
+	limit, ok := l.limits[name]
+	if !ok {
+		limit = l.defaultLimit
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := instrumentID{scope: scope, name: name}
+	ic, ok := l.instruments[id]
+	if !ok {
+		ic = &instrumentCardinality{
+			scope:  scope,
+			name:   name,
+			limit:  limit,
+			values: make(map[attribute.Key]map[attribute.Value]struct{}),
+		}
+		l.instruments[id] = ic
+	}
+	return ic
+}
+
+// report retorna o relatório de todos os instrumentos limitados, ordenados por escopo e nome.
+func (l *cardinalityLimiter) report() []CardinalityReport {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	instruments := make([]*instrumentCardinality, 0, len(l.instruments))
+	for _, ic := range l.instruments {
+		instruments = append(instruments, ic)
+	}
+	l.mu.Unlock()
+
+	reports := make([]CardinalityReport, len(instruments))
+	for i, ic := range instruments {
+		reports[i] = ic.report()
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Scope != reports[j].Scope {
+			return reports[i].Scope < reports[j].Scope
+		}
+		return reports[i].Instrument < reports[j].Instrument
+	})
+	return reports
+}
+
+// instrumentCardinality rastreia os conjuntos de atributos de um instrumento.
+// Séries já aceitas são consultadas em admitted sem travar mu, de forma que o custo
+// do limite no caminho quente é uma busca em sync.Map.
+type instrumentCardinality struct {
+	scope string
+	name  string
+	limit int
+
+	admitted sync.Map // attribute.Distinct -> struct{}
+
+	mu       sync.Mutex
+	series   int
+	values   map[attribute.Key]map[attribute.Value]struct{}
+	overflow int64
+}
+
+// admit retorna o conjunto de attrs se ele já existe ou ainda cabe no limite; caso
+// contrário retorna o conjunto contendo apenas OverflowAttribute. O conjunto retornado
+// deve ser passado com otelmetric.WithAttributeSet, evitando que o SDK o recalcule.
+// É seguro chamar em um receptor nil.
+func (ic *instrumentCardinality) admit(attrs []attribute.KeyValue) attribute.Set {
+	set := attribute.NewSet(attrs...)
+	if ic == nil {
+		return set
+	}
+
+	key := set.Equivalent()
+	if _, ok := ic.admitted.Load(key); ok {
+		return set
+	}
+
+	ic.mu.Lock()
+	if _, ok := ic.admitted.Load(key); ok {
+		ic.mu.Unlock()
+		return set
+	}
+	ic.trackValues(set)
+	// Uma vaga fica reservada para a própria série de overflow
+	if ic.series < ic.limit-1 {
+		ic.series++
+		ic.admitted.Store(key, struct{}{})
+		ic.mu.Unlock()
+		return set
+	}
+	ic.overflow++
+	first := ic.overflow == 1
+	ic.mu.Unlock()
+
+	if first {
+		report := ic.report()
+		otel.Handle(&ErrCardinalityLimitExceeded{
+			Instrument: ic.name,
+			Limit:      ic.limit,
+			TopKeys:    report.TopKeys,
+		})
+	}
+
+	return overflowSet
+}
+
+// trackValues registra os valores distintos de cada chave. Deve ser chamado com mu travado.
+func (ic *instrumentCardinality) trackValues(set attribute.Set) {
+	iter := set.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		values, ok := ic.values[kv.Key]
+		if !ok {
+			values = make(map[attribute.Value]struct{})
+			ic.values[kv.Key] = values
+		}
+		if len(values) < maxTrackedValuesPerKey {
+			values[kv.Value] = struct{}{}
+		}
+	}
+}
+
+// report gera o relatório de cardinalidade do instrumento.
+func (ic *instrumentCardinality) report() CardinalityReport {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	keys := make([]AttributeKeyCardinality, 0, len(ic.values))
+	for k, values := range ic.values {
+		keys = append(keys, AttributeKeyCardinality{Key: string(k), DistinctValues: len(values)})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].DistinctValues != keys[j].DistinctValues {
+			return keys[i].DistinctValues > keys[j].DistinctValues
+		}
+		return keys[i].Key < keys[j].Key
+	})
+
+	return CardinalityReport{
+		Scope:                ic.scope,
+		Instrument:           ic.name,
+		Limit:                ic.limit,
+		Series:               ic.series,
+		OverflowMeasurements: ic.overflow,
+		TopKeys:              keys,
+	}
+}
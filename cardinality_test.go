@@ -0,0 +1,197 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// captureErrors registra um error handler global durante o teste.
+func captureErrors(t *testing.T) func() []error {
+	t.Helper()
+	var mu sync.Mutex
+	var errs []error
+	previous := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(previous) })
+	return func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), errs...)
+	}
+}
+
+func TestNewCardinalityLimiter_Disabled(t *testing.T) {
+	limiter := newCardinalityLimiter(0, nil)
+	if limiter != nil {
+		t.Fatal("esperado limitador nil sem limites configurados")
+	}
+	if ic := limiter.forInstrument("test", "x"); ic != nil {
+		t.Error("forInstrument em limitador nil deve retornar nil")
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("k", "v")}
+	var ic *instrumentCardinality
+	if got := ic.admit(attrs); got.Len() != 1 {
+		t.Error("admit em receptor nil deve retornar os atributos originais")
+	}
+}
+
+func TestCardinalityLimiter_PerInstrumentLimits(t *testing.T) {
+	limiter := newCardinalityLimiter(100, map[string]int{"http_requests_total": 10, "unlimited": 0})
+
+	if ic := limiter.forInstrument("test", "http_requests_total"); ic == nil || ic.limit != 10 {
+		t.Errorf("esperado limite 10 para http_requests_total")
+	}
+	if ic := limiter.forInstrument("test", "other"); ic == nil || ic.limit != 100 {
+		t.Errorf("esperado limite padrão 100")
+	}
+	if ic := limiter.forInstrument("test", "unlimited"); ic != nil {
+		t.Error("limite 0 deve desabilitar o controle para o instrumento")
+	}
+	if limiter.forInstrument("test", "other") != limiter.forInstrument("test", "other") {
+		t.Error("o estado deve ser compartilhado por escopo e nome de instrumento")
+	}
+	if limiter.forInstrument("a", "other") == limiter.forInstrument("b", "other") {
+		t.Error("instrumentos homônimos em escopos diferentes devem ter estados distintos")
+	}
+}
+
+func TestCounter_CardinalityOverflow(t *testing.T) {
+	errs := captureErrors(t)
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader), metric.WithResource(resource.Empty()))
+	limiter := newCardinalityLimiter(0, map[string]int{"http_requests_total": 4})
+	helper := &metricsHelper{meter: provider.Meter("test"), cardinality: limiter}
+
+	counter, err := helper.NewCounter("http_requests_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		counter.Increment(ctx,
+			attribute.String("method", "GET"),
+			attribute.String("path", fmt.Sprintf("/random/%d", i)),
+		)
+	}
+	// Série existente continua aceita
+	counter.Increment(ctx, attribute.String("method", "GET"), attribute.String("path", "/random/0"))
+
+	sum := collectMetrics(t, reader)["http_requests_total"].Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 4 {
+		t.Fatalf("esperado 3 séries + overflow, obtido %d pontos", len(sum.DataPoints))
+	}
+
+	var overflow int64
+	for _, dp := range sum.DataPoints {
+		if v, ok := dp.Attributes.Value("otel.metric.overflow"); ok && v.AsBool() {
+			overflow = dp.Value
+		}
+	}
+	if overflow != 7 {
+		t.Errorf("esperado 7 medições em overflow, obtido %d", overflow)
+	}
+
+	var limitErrs []*ErrCardinalityLimitExceeded
+	for _, err := range errs() {
+		var target *ErrCardinalityLimitExceeded
+		if errors.As(err, &target) {
+			limitErrs = append(limitErrs, target)
+		}
+	}
+	if len(limitErrs) != 1 {
+		t.Fatalf("esperado exatamente um aviso, obtido %d", len(limitErrs))
+	}
+	if limitErrs[0].Instrument != "http_requests_total" || limitErrs[0].TopKeys[0].Key != "path" {
+		t.Errorf("aviso inesperado: %v", limitErrs[0])
+	}
+
+	reports := limiter.report()
+	if len(reports) != 1 {
+		t.Fatalf("esperado 1 relatório, obtido %d", len(reports))
+	}
+	report := reports[0]
+	if !report.Overflowed() || report.Series != 3 || report.OverflowMeasurements != 7 {
+		t.Errorf("relatório inesperado: %+v", report)
+	}
+	if report.TopKeys[0].Key != "path" || report.TopKeys[0].DistinctValues != 10 {
+		t.Errorf("esperado path como chave de maior cardinalidade, obtido %+v", report.TopKeys)
+	}
+}
+
+func TestClient_CardinalityReport(t *testing.T) {
+	cl, err := NewClient(NewConfig("test-service").
+		WithMetricCardinalityLimit(2).
+		WithErrorHandler(func(error) {}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reporter, ok := cl.(CardinalityReporter)
+	if !ok {
+		t.Fatal("Client deveria implementar CardinalityReporter")
+	}
+	if report := reporter.CardinalityReport(); len(report) != 0 {
+		t.Errorf("esperado relatório vazio, obtido %+v", report)
+	}
+
+	histogram, err := cl.NewMetricsHelper("test").NewHistogram("latency", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	histogram.Record(ctx, 1, attribute.String("tenant", "a"))
+	histogram.Record(ctx, 1, attribute.String("tenant", "b"))
+
+	report := reporter.CardinalityReport()
+	if len(report) != 1 || report[0].Scope != "test" || report[0].Instrument != "latency" || !report[0].Overflowed() {
+		t.Errorf("relatório inesperado: %+v", report)
+	}
+}
+
+func TestConfig_MetricCardinalityLimit(t *testing.T) {
+	config := NewConfig("test-service").
+		WithMetricCardinalityLimit(1000).
+		WithInstrumentCardinalityLimit("http_requests_total", 200)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() erro = %v", err)
+	}
+	if config.MetricCardinalityLimits["http_requests_total"] != 200 {
+		t.Error("limite por instrumento não registrado")
+	}
+
+	invalid := config.WithMetricCardinalityLimit(-1)
+	if err := invalid.Validate(); err == nil {
+		t.Error("esperado erro para limite negativo")
+	}
+}
+
+func BenchmarkCounter_AddWithCardinalityLimit(b *testing.B) {
+	provider := metric.NewMeterProvider(metric.WithReader(metric.NewManualReader()), metric.WithResource(resource.Empty()))
+	helper := &metricsHelper{meter: provider.Meter("test"), scope: "test", cardinality: newCardinalityLimiter(1000, nil)}
+	counter, _ := helper.NewCounter("requests_total", "Requisições")
+	ctx := context.Background()
+	route, status := attribute.String("route", "/users"), attribute.Int("status", 200)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			counter.Add(ctx, 1, route, status)
+		}
+	})
+}
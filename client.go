@@ -23,10 +23,15 @@ package graftel
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
+	promclient "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
@@ -46,7 +51,7 @@ import (
 // Client gerencia a inicialização e uso do OpenTelemetry.
 // É a interface principal para trabalhar com métricas e logs.
 type Client interface {
-	// Initialize inicializa o OpenTelemetry com métricas e logs.
+	// Initialize inicializa o OpenTelemetry com métricas, logs e traces.
 	// Deve ser chamado antes de usar qualquer funcionalidade.
 	Initialize(ctx context.Context) error
 
@@ -84,9 +89,21 @@ type client struct {
 	loggerProvider     *log.LoggerProvider
 	traceProvider      *sdktrace.TracerProvider
 	prometheusExporter *prometheus.Exporter
+	pusher             *metricsPusher
+	debug              *debugState
+	console            *consoleWriter
 	resource           *resource.Resource
+	queues             []*persistentQueue
+	queueMetrics       otelmetric.Registration
+	runtimeMetrics     otelmetric.Registration
+	hostMetrics        otelmetric.Registration
+	cardinality        *cardinalityLimiter
+	instruments        *instrumentRegistry
 }
 
+// instrumentationName é o nome do escopo usado pelas métricas internas do graftel.
+const instrumentationName = "github.com/CristianSsousa/graftel/v2"
+
 // NewClient cria uma nova instância do cliente OpenTelemetry.
 // A configuração é validada antes de criar o cliente.
 func NewClient(config Config) (Client, error) {
@@ -100,14 +117,30 @@ func NewClient(config Config) (Client, error) {
 		return nil, fmt.Errorf("falha ao criar resource: %w", err)
 	}
 
-	return &client{
-		config:   config,
-		resource: res,
-	}, nil
+	c := &client{
+		config:      config,
+		resource:    res,
+		cardinality: newCardinalityLimiter(config.MetricCardinalityLimit, config.MetricCardinalityLimits),
+		instruments: newInstrumentRegistry(),
+		console:     newConsoleWriter(config.Console),
+	}
+	if config.Debug {
+		var producers []sdkmetric.Producer
+		if config.RuntimeMetrics {
+			producers = append(producers, newRuntimeProducer())
+		}
+		c.debug = newDebugState(config.DebugBufferSize, producers...)
+	}
+	return c, nil
 }
 
-// Initialize inicializa o OpenTelemetry com métricas e logs.
+// Initialize inicializa o OpenTelemetry com métricas, logs e traces.
 func (c *client) Initialize(ctx context.Context) error {
+	// Registrar handler de erros antes de criar os providers
+	if c.config.ErrorHandler != nil {
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(c.config.ErrorHandler))
+	}
+
 	// Inicializar métricas
 	if err := c.initializeMetrics(ctx); err != nil {
 		return fmt.Errorf("falha ao inicializar métricas: %w", err)
@@ -118,6 +151,43 @@ func (c *client) Initialize(ctx context.Context) error {
 		return fmt.Errorf("falha ao inicializar logs: %w", err)
 	}
 
+	// Inicializar traces
+	if err := c.initializeTraces(ctx); err != nil {
+		return fmt.Errorf("falha ao inicializar traces: %w", err)
+	}
+
+	// Registrar métricas de backlog das filas persistentes
+	if len(c.queues) > 0 {
+		reg, err := registerQueueMetrics(c.GetMeter(instrumentationName), c.queues)
+		if err != nil {
+			return fmt.Errorf("falha ao registrar métricas da fila persistente: %w", err)
+		}
+		c.queueMetrics = reg
+	}
+
+	// Registrar métricas de runtime e do processo
+	if c.config.RuntimeMetrics {
+		reg, err := registerRuntimeMetrics(c.GetMeter(instrumentationName), "/proc")
+		if err != nil {
+			return fmt.Errorf("falha ao registrar métricas de runtime: %w", err)
+		}
+		c.runtimeMetrics = reg
+	}
+
+	// Registrar métricas de host
+	if c.config.HostMetrics {
+		reg, err := registerHostMetrics(c.GetMeter(instrumentationName), "/proc", c.config.HostMetricsCacheTTL)
+		switch {
+		case errors.Is(err, errHostMetricsUnavailable):
+			// Fora do Linux as métricas de host são ignoradas com um aviso
+			otel.Handle(fmt.Errorf("graftel: métricas de host desabilitadas: %w", err))
+		case err != nil:
+			return fmt.Errorf("falha ao registrar métricas de host: %w", err)
+		default:
+			c.hostMetrics = reg
+		}
+	}
+
 	return nil
 }
 
@@ -127,12 +197,51 @@ func (c *client) initializeMetrics(ctx context.Context) error {
 
 	// Se PrometheusEndpoint estiver configurado, criar exporter Prometheus
 	if c.config.PrometheusEndpoint != "" {
-		exporter, err := prometheus.New()
+		var opts []prometheus.Option
+		for _, producer := range c.metricProducers(true) {
+			opts = append(opts, prometheus.WithProducer(producer))
+		}
+		exporter, err := prometheus.New(opts...)
 		if err != nil {
 			return fmt.Errorf("falha ao criar exporter Prometheus: %w", err)
 		}
 		c.prometheusExporter = exporter
 		reader = exporter
+	} else if c.config.Push.Endpoint != "" {
+		// Modo push: coleta sob demanda em um registry privado, enviado no Shutdown
+		registry := promclient.NewRegistry()
+		opts := []prometheus.Option{prometheus.WithRegisterer(registry)}
+		for _, producer := range c.metricProducers(false) {
+			opts = append(opts, prometheus.WithProducer(producer))
+		}
+		exporter, err := prometheus.New(opts...)
+		if err != nil {
+			return fmt.Errorf("falha ao criar exporter para o modo push: %w", err)
+		}
+
+		job, instance := c.config.Push.groupingKey(c.resource)
+		c.pusher = &metricsPusher{
+			config:     c.config.Push,
+			gatherer:   registry,
+			job:        job,
+			instance:   instance,
+			httpClient: &http.Client{Timeout: c.config.ExportTimeout},
+		}
+		if c.config.APIKey != "" {
+			c.pusher.authHeader = buildAuthHeader(c.config.InstanceID, c.config.APIKey)
+		}
+		reader = exporter
+	} else if c.config.StatsD.Endpoint != "" {
+		// Agente StatsD/DogStatsD via UDP
+		exporter, err := NewStatsDExporter(c.config.StatsD)
+		if err != nil {
+			return err
+		}
+
+		// A ponte com o Prometheus e os histogramas de runtime não são usados: são cumulativos
+		reader = sdkmetric.NewPeriodicReader(exporter,
+			sdkmetric.WithInterval(c.config.MetricExportInterval),
+		)
 	} else {
 		// Caso contrário, usar OTLP HTTP
 		// Parse da URL para extrair endpoint e path
@@ -162,6 +271,11 @@ func (c *client) initializeMetrics(ctx context.Context) error {
 		// Configurar timeout
 		opts = append(opts, otlpmetrichttp.WithTimeout(c.config.ExportTimeout))
 
+		// Configurar temporalidade
+		opts = append(opts, otlpmetrichttp.WithTemporalitySelector(
+			temporalitySelector(c.config.MetricTemporality, c.config.MetricTemporalityOverrides),
+		))
+
 		// Configurar autenticação se APIKey fornecida
 		if c.config.APIKey != "" {
 			authHeader := buildAuthHeader(c.config.InstanceID, c.config.APIKey)
@@ -170,21 +284,44 @@ func (c *client) initializeMetrics(ctx context.Context) error {
 			}))
 		}
 
+		// Configurar fila persistente, se habilitada
+		httpClient, err := c.exportHTTPClient("metrics")
+		if err != nil {
+			return fmt.Errorf("falha ao criar fila persistente: %w", err)
+		}
+		if httpClient != nil {
+			opts = append(opts, otlpmetrichttp.WithHTTPClient(httpClient))
+		}
+
 		exporter, err := otlpmetrichttp.New(ctx, opts...)
 		if err != nil {
 			return fmt.Errorf("falha ao criar exporter OTLP: %w", err)
 		}
 
-		reader = sdkmetric.NewPeriodicReader(exporter,
+		readerOpts := []sdkmetric.PeriodicReaderOption{
 			sdkmetric.WithInterval(c.config.MetricExportInterval),
-		)
+		}
+		// Métricas de collectors Prometheus legados e histogramas de runtime, se configurados
+		for _, producer := range c.metricProducers(false) {
+			readerOpts = append(readerOpts, sdkmetric.WithProducer(producer))
+		}
+
+		reader = sdkmetric.NewPeriodicReader(exporter, readerOpts...)
 	}
 
-	// Criar MeterProvider
-	meterProvider := sdkmetric.NewMeterProvider(
+	providerOpts := []sdkmetric.Option{
 		sdkmetric.WithResource(c.resource),
 		sdkmetric.WithReader(reader),
-	)
+		sdkmetric.WithView(sdkViews(c.config.MetricViews)...),
+		sdkmetric.WithExemplarFilter(c.config.ExemplarFilter.sdkFilter()),
+	}
+	// Reader manual do DebugHandler, coletado apenas sob demanda
+	if c.debug != nil {
+		providerOpts = append(providerOpts, sdkmetric.WithReader(c.debug.reader))
+	}
+
+	// Criar MeterProvider
+	meterProvider := sdkmetric.NewMeterProvider(providerOpts...)
 
 	c.meterProvider = meterProvider
 	otel.SetMeterProvider(meterProvider)
@@ -192,12 +329,48 @@ func (c *client) initializeMetrics(ctx context.Context) error {
 	return nil
 }
 
+// metricProducers retorna os producers anexados ao reader: os collectors Prometheus
+// legados e, com RuntimeMetrics, os histogramas de runtime.
+func (c *client) metricProducers(prometheusEnabled bool) []sdkmetric.Producer {
+	producers := prometheusProducers(c.config.PrometheusGatherers, prometheusEnabled)
+	if c.config.RuntimeMetrics {
+		producers = append(producers, newRuntimeProducer())
+	}
+	return producers
+}
+
 // initializeLogs configura o provider de logs.
 func (c *client) initializeLogs(ctx context.Context) error {
+	exporter := c.config.LogExporter
+	if exporter == nil {
+		var err error
+		if exporter, err = c.otlpLogExporter(ctx); err != nil {
+			return err
+		}
+	}
+
+	providerOpts := []log.LoggerProviderOption{
+		log.WithResource(c.resource),
+		log.WithProcessor(c.logProcessor(exporter)),
+	}
+	if c.debug != nil {
+		providerOpts = append(providerOpts, log.WithProcessor(debugLogProcessor{ring: c.debug.logs}))
+	}
+
+	// Criar LoggerProvider
+	loggerProvider := log.NewLoggerProvider(providerOpts...)
+
+	c.loggerProvider = loggerProvider
+
+	return nil
+}
+
+// otlpLogExporter cria o exporter OTLP/HTTP de logs.
+func (c *client) otlpLogExporter(ctx context.Context) (log.Exporter, error) {
 	// Parse da URL para extrair endpoint e path
 	endpoint, urlPath, err := parseOTLPEndpoint(c.config.OTLPEndpoint)
 	if err != nil {
-		return fmt.Errorf("falha ao processar endpoint OTLP: %w", err)
+		return nil, fmt.Errorf("falha ao processar endpoint OTLP: %w", err)
 	}
 
 	opts := []otlploghttp.Option{
@@ -229,20 +402,66 @@ func (c *client) initializeLogs(ctx context.Context) error {
 		}))
 	}
 
+	// Configurar fila persistente, se habilitada
+	httpClient, err := c.exportHTTPClient("logs")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar fila persistente: %w", err)
+	}
+	if httpClient != nil {
+		opts = append(opts, otlploghttp.WithHTTPClient(httpClient))
+	}
+
 	exporter, err := otlploghttp.New(ctx, opts...)
 	if err != nil {
-		return fmt.Errorf("falha ao criar exporter de logs OTLP: %w", err)
+		return nil, fmt.Errorf("falha ao criar exporter de logs OTLP: %w", err)
 	}
+	return exporter, nil
+}
 
-	// Criar LoggerProvider
-	loggerProvider := log.NewLoggerProvider(
-		log.WithResource(c.resource),
-		log.WithProcessor(log.NewBatchProcessor(exporter)),
-	)
+// logProcessor cria o processor de logs conforme LogBatch e LogExportInterval.
+func (c *client) logProcessor(exporter log.Exporter) log.Processor {
+	batch := c.config.LogBatch
+	if batch.Synchronous {
+		return log.NewSimpleProcessor(exporter)
+	}
 
-	c.loggerProvider = loggerProvider
+	opts := []log.BatchProcessorOption{
+		log.WithExportInterval(c.config.LogExportInterval),
+	}
+	if batch.MaxQueueSize > 0 {
+		opts = append(opts, log.WithMaxQueueSize(batch.MaxQueueSize))
+	}
+	if batch.MaxExportBatchSize > 0 {
+		opts = append(opts, log.WithExportMaxBatchSize(batch.MaxExportBatchSize))
+	}
+	if batch.ExportTimeout > 0 {
+		opts = append(opts, log.WithExportTimeout(batch.ExportTimeout))
+	}
 
-	return nil
+	return log.NewBatchProcessor(exporter, opts...)
+}
+
+// spanProcessorOption cria a opção de processor de spans conforme TraceBatch e TraceExportInterval.
+func (c *client) spanProcessorOption(exporter sdktrace.SpanExporter) sdktrace.TracerProviderOption {
+	batch := c.config.TraceBatch
+	if batch.Synchronous {
+		return sdktrace.WithSyncer(exporter)
+	}
+
+	opts := []sdktrace.BatchSpanProcessorOption{
+		sdktrace.WithBatchTimeout(c.config.TraceExportInterval),
+	}
+	if batch.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(batch.MaxQueueSize))
+	}
+	if batch.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(batch.MaxExportBatchSize))
+	}
+	if batch.ExportTimeout > 0 {
+		opts = append(opts, sdktrace.WithExportTimeout(batch.ExportTimeout))
+	}
+
+	return sdktrace.WithBatcher(exporter, opts...)
 }
 
 // GetMeter retorna um Meter para criar métricas.
@@ -274,14 +493,108 @@ func (c *client) GetPrometheusExporter() *prometheus.Exporter {
 	return c.prometheusExporter
 }
 
-// NewMetricsHelper cria um helper para facilitar o uso de métricas.
+// PrometheusHandler implementa PrometheusHandlerProvider.
+func (c *client) PrometheusHandler() http.Handler {
+	if c.prometheusExporter == nil {
+		return nil
+	}
+	return prometheusHandler(promclient.DefaultGatherer)
+}
+
+// DebugHandler implementa DebugHandlerProvider.
+func (c *client) DebugHandler() http.Handler {
+	if c.debug == nil {
+		return http.NotFoundHandler()
+	}
+	return c.debug
+}
+
+// NewMetricsHelper cria um helper para facilitar o uso de métricas. As tags do contexto
+// e o modo estrito de schemas vêm de Config.MetricContextTags e Config.StrictMetricSchemas.
 func (c *client) NewMetricsHelper(name string) MetricsHelper {
-	return NewMetricsHelper(c.GetMeter(name))
+	return &metricsHelper{
+		meter:         c.GetMeter(name),
+		scope:         name,
+		registry:      c.instruments,
+		cardinality:   c.cardinality,
+		tags:          newContextTagFilter(c.config.MetricContextTags),
+		strictSchemas: c.config.StrictMetricSchemas,
+	}
 }
 
-// NewLogsHelper cria um helper para facilitar o uso de logs.
+// CardinalityReport implementa CardinalityReporter.
+func (c *client) CardinalityReport() []CardinalityReport {
+	return c.cardinality.report()
+}
+
+// NewLogsHelper cria um helper para facilitar o uso de logs, com o nível mínimo
+// configurado para o logger name (veja Config.LogLevel e Config.LogLevelOverrides).
 func (c *client) NewLogsHelper(name string) LogsHelper {
-	return NewLogsHelper(c.GetLogger(name))
+	h := &logsHelper{
+		logger:   c.GetLogger(name),
+		name:     name,
+		minLevel: c.config.loggerLevel(name),
+		console:  c.console,
+	}
+	if !c.config.DisableFatalExit {
+		h.exit = c.exitAfterFatal
+	}
+	return h
+}
+
+// exitAfterFatal faz o flush dos providers, limitado por FatalFlushTimeout, e encerra o
+// processo com FatalExitCode. No modo push, o snapshot das métricas também é enviado.
+func (c *client) exitAfterFatal(ctx context.Context) {
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.config.FatalFlushTimeout)
+	if err := c.ForceFlush(flushCtx); err != nil {
+		otel.Handle(fmt.Errorf("graftel: erro no flush após Fatal: %w", err))
+	}
+	cancel()
+
+	exit := c.config.ExitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(c.config.FatalExitCode)
+}
+
+// Flusher é implementado pelo Client retornado por NewClient. Adaptadores de logging
+// o usam para enviar os registros pendentes antes de o processo encerrar (ex: Fatal do
+// zap ou do logrus), sem depender da interface Client:
+//
+//	if f, ok := client.(graftel.Flusher); ok {
+//		f.ForceFlush(ctx)
+//	}
+type Flusher interface {
+	// ForceFlush envia as métricas, os spans e os logs pendentes. No modo push, também
+	// envia o snapshot atual das métricas.
+	ForceFlush(ctx context.Context) error
+}
+
+// ForceFlush implementa Flusher.
+func (c *client) ForceFlush(ctx context.Context) error {
+	var errs []error
+	if c.pusher != nil {
+		if err := c.pusher.Push(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao enviar métricas no modo push: %w", err))
+		}
+	}
+	if c.meterProvider != nil {
+		if err := c.meterProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("erro no flush do meter provider: %w", err))
+		}
+	}
+	if c.traceProvider != nil {
+		if err := c.traceProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("erro no flush do trace provider: %w", err))
+		}
+	}
+	if c.loggerProvider != nil {
+		if err := c.loggerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("erro no flush do logger provider: %w", err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // GetTracer retorna um Tracer para criar spans e traces.
@@ -327,15 +640,28 @@ func (c *client) initializeTraces(ctx context.Context) error {
 		}))
 	}
 
+	httpClient, err := c.exportHTTPClient("traces")
+	if err != nil {
+		return fmt.Errorf("falha ao criar fila persistente: %w", err)
+	}
+	if httpClient != nil {
+		opts = append(opts, otlptracehttp.WithHTTPClient(httpClient))
+	}
+
 	exporter, err := otlptracehttp.New(ctx, opts...)
 	if err != nil {
 		return fmt.Errorf("falha ao criar exporter de traces OTLP: %w", err)
 	}
 
-	traceProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	providerOpts := []sdktrace.TracerProviderOption{
+		c.spanProcessorOption(exporter),
 		sdktrace.WithResource(c.resource),
-	)
+	}
+	if c.debug != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(debugSpanProcessor{ring: c.debug.spans}))
+	}
+
+	traceProvider := sdktrace.NewTracerProvider(providerOpts...)
 
 	c.traceProvider = traceProvider
 	otel.SetTracerProvider(traceProvider)
@@ -343,10 +669,66 @@ func (c *client) initializeTraces(ctx context.Context) error {
 	return nil
 }
 
+// exportHTTPClient retorna o http.Client usado pelo exporter OTLP do sinal informado.
+// Retorna nil quando a fila persistente não está habilitada, mantendo o client padrão do exporter.
+func (c *client) exportHTTPClient(signal string) (*http.Client, error) {
+	if c.config.PersistentQueueDir == "" {
+		return nil, nil
+	}
+
+	// Credenciais são reaplicadas no reenvio e nunca gravadas em disco
+	headers := make(map[string]string)
+	if c.config.APIKey != "" {
+		headers["Authorization"] = buildAuthHeader(c.config.InstanceID, c.config.APIKey)
+	}
+
+	queue, err := newPersistentQueue(signal,
+		filepath.Join(c.config.PersistentQueueDir, signal),
+		c.config.PersistentQueueMaxBytes,
+		headers,
+		nil,
+		c.config.ExportTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.queues = append(c.queues, queue)
+
+	return &http.Client{
+		Transport: queue,
+		Timeout:   c.config.ExportTimeout,
+	}, nil
+}
+
 // Shutdown encerra o cliente OpenTelemetry de forma segura.
 func (c *client) Shutdown(ctx context.Context) error {
 	var errs []error
 
+	if c.queueMetrics != nil {
+		if err := c.queueMetrics.Unregister(); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao remover métricas da fila persistente: %w", err))
+		}
+	}
+
+	if c.runtimeMetrics != nil {
+		if err := c.runtimeMetrics.Unregister(); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao remover métricas de runtime: %w", err))
+		}
+	}
+
+	if c.hostMetrics != nil {
+		if err := c.hostMetrics.Unregister(); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao remover métricas de host: %w", err))
+		}
+	}
+
+	// Modo push: envia o snapshot final antes de encerrar o meter provider
+	if c.pusher != nil {
+		if err := c.pusher.Push(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao enviar métricas no modo push: %w", err))
+		}
+	}
+
 	if c.meterProvider != nil {
 		if err := c.meterProvider.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("erro ao encerrar meter provider: %w", err))
@@ -365,6 +747,13 @@ func (c *client) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Fechar as filas por último: o flush dos providers pode gravar nelas
+	for _, queue := range c.queues {
+		if err := queue.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("erro ao fechar fila persistente de %s: %w", queue.signal, err))
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("erros ao encerrar: %v", errs)
 	}
@@ -3,6 +3,8 @@ package graftel
 import (
 	"context"
 	"testing"
+
+	"go.opentelemetry.io/otel/sdk/log"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -152,3 +154,47 @@ func TestClient_initializeTraces(t *testing.T) {
 		}
 	}()
 }
+
+func TestClient_logProcessor(t *testing.T) {
+	exporter := &noopLogExporter{}
+
+	c := &client{config: NewConfig("test-service").WithLogBatch(BatchConfig{Synchronous: true})}
+	if _, ok := c.logProcessor(exporter).(*log.SimpleProcessor); !ok {
+		t.Error("esperado SimpleProcessor para LogBatch.Synchronous")
+	}
+
+	c = &client{config: NewConfig("test-service").WithLogBatch(BatchConfig{MaxQueueSize: 10, MaxExportBatchSize: 5})}
+	processor, ok := c.logProcessor(exporter).(*log.BatchProcessor)
+	if !ok {
+		t.Fatal("esperado BatchProcessor por padrão")
+	}
+	_ = processor.Shutdown(context.Background())
+}
+
+func TestClient_Initialize_Traces(t *testing.T) {
+	cl, err := NewClient(NewConfig("test-service").WithInsecure(true).WithSynchronousExport(true))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := cl.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	defer func() {
+		if err := cl.Shutdown(ctx); err != nil {
+			t.Logf("Erro ao encerrar cliente: %v", err)
+		}
+	}()
+
+	if cl.(*client).traceProvider == nil {
+		t.Error("Initialize() deveria inicializar o trace provider")
+	}
+}
+
+// noopLogExporter é um exporter de logs que descarta todos os registros.
+type noopLogExporter struct{}
+
+func (noopLogExporter) Export(context.Context, []log.Record) error { return nil }
+func (noopLogExporter) Shutdown(context.Context) error             { return nil }
+func (noopLogExporter) ForceFlush(context.Context) error           { return nil }
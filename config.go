@@ -4,9 +4,39 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
+// defaultPersistentQueueMaxBytes é o tamanho máximo padrão da fila persistente de cada sinal.
+const defaultPersistentQueueMaxBytes = 256 << 20
+
+// BatchConfig ajusta o processamento em lote de um sinal (logs ou traces).
+// Campos com valor zero mantêm o padrão do SDK OpenTelemetry, que também
+// respeita as variáveis OTEL_BLRP_* e OTEL_BSP_*.
+type BatchConfig struct {
+	// MaxQueueSize é o número máximo de itens aguardando exportação.
+	// Itens excedentes são descartados.
+	MaxQueueSize int
+
+	// MaxExportBatchSize é o número máximo de itens por exportação.
+	// Não pode ser maior que MaxQueueSize.
+	MaxExportBatchSize int
+
+	// ExportTimeout é o tempo máximo de uma exportação em lote.
+	ExportTimeout time.Duration
+
+	// Synchronous usa um processor simples, que exporta cada item no momento
+	// em que é emitido. Útil para jobs de curta duração; não recomendado em
+	// serviços com alto volume.
+	Synchronous bool
+}
+
 // Config contém as configurações para inicializar o OpenTelemetry.
 // Use NewConfig para criar uma configuração com valores padrão.
 type Config struct {
@@ -34,23 +64,183 @@ type Config struct {
 	// Se vazio, não expõe endpoint Prometheus.
 	PrometheusEndpoint string
 
+	// StatsD exporta as métricas para um agente StatsD/DogStatsD via UDP em vez de OTLP.
+	// Habilitado quando StatsD.Endpoint é definido (ou GRAFTEL_STATSD_ENDPOINT).
+	// Não pode ser combinado com PrometheusEndpoint. Veja WithStatsD.
+	StatsD StatsDConfig
+
+	// Push habilita o modo push para jobs de curta duração: as métricas são coletadas
+	// sob demanda e um único snapshot é enviado ao Pushgateway ou via remote-write no
+	// Shutdown. Habilitado quando Push.Endpoint é definido (ou GRAFTEL_PUSH_ENDPOINT).
+	// Não pode ser combinado com PrometheusEndpoint nem StatsD. Veja WithPushOnShutdown.
+	Push PushConfig
+
+	// PrometheusGatherers são gatherers de collectors Prometheus legados cujas métricas são
+	// exportadas junto com as do Client. Com GRAFTEL_PROMETHEUS_BRIDGE=true, usa
+	// prometheus.DefaultGatherer. Veja WithPrometheusBridge.
+	PrometheusGatherers []promclient.Gatherer
+
 	// ResourceAttributes são atributos adicionais para o resource.
 	ResourceAttributes map[string]string
 
+	// MetricViews customizam buckets de histogramas, atributos e nomes dos instrumentos.
+	// Veja WithHistogramBuckets, WithExponentialHistogram, WithMetricAttributes,
+	// WithoutMetricAttributes e WithMetricRename.
+	MetricViews []MetricView
+
+	// MetricTemporality é a preferência de temporalidade das métricas exportadas via OTLP.
+	// Pode ser configurada via OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE ou WithMetricTemporality.
+	// Padrão: TemporalityCumulative
+	MetricTemporality MetricTemporality
+
+	// MetricTemporalityOverrides sobrescreve a temporalidade de tipos de instrumento específicos,
+	// independentemente de MetricTemporality.
+	MetricTemporalityOverrides map[sdkmetric.InstrumentKind]metricdata.Temporality
+
+	// ExemplarFilter define quais medições viram exemplars ligados ao trace ativo.
+	// Pode ser configurado via OTEL_METRICS_EXEMPLAR_FILTER ou WithExemplarFilter.
+	// Padrão: ExemplarFilterTraceBased
+	ExemplarFilter ExemplarFilter
+
+	// MetricCardinalityLimit é o número máximo de conjuntos de atributos distintos por
+	// instrumento criado pelo MetricsHelper do Client, incluindo a série de overflow.
+	// Medições excedentes são agregadas na série otel.metric.overflow=true.
+	// Pode ser configurado via GRAFTEL_METRIC_CARDINALITY_LIMIT ou WithMetricCardinalityLimit.
+	// Padrão: 0 (sem limite)
+	MetricCardinalityLimit int
+
+	// MetricCardinalityLimits sobrescreve MetricCardinalityLimit por nome de instrumento.
+	MetricCardinalityLimits map[string]int
+
+	// MetricContextTags são as chaves de tags do contexto (veja WithTags) anexadas às
+	// medições dos instrumentos síncronos criados por Client.NewMetricsHelper, exceto os
+	// vinculados com Bind (veja WithContextTags).
+	// Pode ser configurado via GRAFTEL_METRIC_CONTEXT_TAGS (separadas por vírgula)
+	// ou WithMetricContextTags.
+	MetricContextTags []string
+
+	// StrictMetricSchemas faz as violações de schema de CounterVec, UpDownCounterVec e
+	// HistogramVec criados a partir de Client.NewMetricsHelper causarem panic em vez de
+	// descartar a medição. Indicado para testes.
+	// Pode ser configurado via GRAFTEL_STRICT_METRIC_SCHEMAS ou WithStrictMetricSchemas.
+	// Padrão: false
+	StrictMetricSchemas bool
+
 	// MetricExportInterval é o intervalo de exportação de métricas.
 	// Padrão: 30 segundos
 	MetricExportInterval time.Duration
 
+	// RuntimeMetrics registra em Initialize as métricas do runtime Go (goroutines, memória,
+	// GC, scheduler, GOMAXPROCS, cgo) e, no Linux, de CPU, memória e descritores do processo.
+	// Pode ser configurado via GRAFTEL_RUNTIME_METRICS ou WithRuntimeMetrics.
+	// Padrão: false
+	RuntimeMetrics bool
+
+	// HostMetrics registra em Initialize as métricas system.* do host (CPU, memória,
+	// disco e rede) lidas de /proc. Disponível apenas no Linux; nos demais sistemas é
+	// ignorado com um aviso enviado ao ErrorHandler.
+	// Pode ser configurado via GRAFTEL_HOST_METRICS ou WithHostMetrics.
+	// Padrão: false
+	HostMetrics bool
+
+	// HostMetricsCacheTTL é o tempo durante o qual uma leitura de /proc é reaproveitada
+	// pelas métricas de host. Não agenda coletas: os valores são lidos quando o reader
+	// coleta (MetricExportInterval ou scrape Prometheus), e coletas mais próximas que
+	// HostMetricsCacheTTL recebem o último snapshot.
+	// Pode ser configurado via GRAFTEL_HOST_METRICS_CACHE_TTL.
+	// Padrão: MetricExportInterval
+	HostMetricsCacheTTL time.Duration
+
+	// Debug habilita DebugHandlerProvider.DebugHandler: um reader manual de métricas e
+	// buffers em memória com os logs e spans mais recentes.
+	// Pode ser configurado via GRAFTEL_DEBUG ou WithDebugHandler.
+	// Padrão: false
+	Debug bool
+
+	// DebugBufferSize é o número de logs e de spans mantidos em memória pelo DebugHandler.
+	// Pode ser configurado via GRAFTEL_DEBUG_BUFFER_SIZE.
+	// Padrão: DefaultDebugBufferSize
+	DebugBufferSize int
+
+	// LogLevel é o nível mínimo dos logs emitidos pelos LogsHelper do Client, tanto no
+	// console quanto via OpenTelemetry.
+	// Pode ser configurado via GRAFTEL_LOG_LEVEL (ex: "info,billing/http=warn") ou WithLogLevel.
+	// Padrão: LogLevelTrace (todos os níveis)
+	LogLevel LogLevel
+
+	// LogLevelOverrides sobrescreve LogLevel por nome de logger. Uma chave vale para o
+	// logger de mesmo nome e para os nomes abaixo dela (ex: "billing" vale para
+	// "billing/http"); a chave mais específica prevalece.
+	LogLevelOverrides map[string]LogLevel
+
+	// Console configura a impressão dos logs dos LogsHelper no console: formato (texto,
+	// texto colorido, JSON, logfmt ou desabilitado), destino, horário, nível, nome do
+	// logger e trace IDs. Pode ser configurado via GRAFTEL_CONSOLE_* ou WithConsole.
+	// Padrão: "msg [chave:valor]..." em os.Stderr
+	Console ConsoleConfig
+
+	// DisableFatalExit faz Fatal e FatalWithFields apenas emitirem o log, sem encerrar o
+	// processo (comportamento anterior).
+	// Pode ser configurado via GRAFTEL_DISABLE_FATAL_EXIT ou WithFatalExit(false).
+	// Padrão: false (Fatal faz o flush dos providers e encerra o processo)
+	DisableFatalExit bool
+
+	// FatalExitCode é o código de saída do processo após um log Fatal.
+	// Pode ser configurado via GRAFTEL_FATAL_EXIT_CODE ou WithFatalExitCode.
+	// Padrão: 1
+	FatalExitCode int
+
+	// FatalFlushTimeout limita o flush dos providers antes de encerrar após um log Fatal.
+	// Pode ser configurado via GRAFTEL_FATAL_FLUSH_TIMEOUT ou WithFatalFlushTimeout.
+	// Padrão: 5 segundos
+	FatalFlushTimeout time.Duration
+
+	// ExitFunc encerra o processo após um log Fatal. Substitua em testes para verificar
+	// o encerramento sem sair do processo.
+	// Padrão: os.Exit
+	ExitFunc func(code int)
+
 	// LogExportInterval é o intervalo de exportação de logs.
 	// Padrão: 30 segundos
 	LogExportInterval time.Duration
 
+	// TraceExportInterval é o intervalo de exportação de traces.
+	// Padrão: 5 segundos
+	TraceExportInterval time.Duration
+
+	// LogBatch ajusta o processamento em lote de logs.
+	LogBatch BatchConfig
+
+	// LogExporter substitui o exporter OTLP de logs, por exemplo por um exporter em
+	// memória nos testes. Os registros continuam passando pelo processor de LogBatch.
+	LogExporter sdklog.Exporter
+
+	// TraceBatch ajusta o processamento em lote de traces.
+	TraceBatch BatchConfig
+
 	// ExportTimeout é o timeout para exportação de dados OTLP.
 	// Padrão: 10 segundos
 	ExportTimeout time.Duration
 
 	// Insecure desabilita TLS (apenas para desenvolvimento local).
 	Insecure bool
+
+	// ErrorHandler recebe erros e avisos do OpenTelemetry e do graftel, como falhas de
+	// exportação e limites de cardinalidade excedidos. Se definido, é registrado como
+	// handler global do OpenTelemetry em Initialize.
+	ErrorHandler func(error)
+
+	// PersistentQueueDir é o diretório da fila persistente de exportação.
+	// Se definido, cada sinal (logs, metrics, traces) usa um subdiretório próprio onde
+	// as exportações OTLP que falharem são gravadas e reenviadas em ordem quando o
+	// endpoint voltar, inclusive após um restart.
+	// Pode ser configurado via GRAFTEL_PERSISTENT_QUEUE_DIR ou WithPersistentQueue.
+	PersistentQueueDir string
+
+	// PersistentQueueMaxBytes é o tamanho máximo em disco da fila de cada sinal.
+	// Ao atingir o limite, os registros mais antigos são descartados.
+	// Padrão: 256 MiB
+	PersistentQueueMaxBytes int64
 }
 
 // NewConfig cria uma nova configuração com valores padrão.
@@ -65,6 +255,7 @@ func NewConfig(serviceName string) Config {
 		OTLPEndpoint:         "http://localhost:4318",
 		MetricExportInterval: 30 * time.Second,
 		LogExportInterval:    30 * time.Second,
+		TraceExportInterval:  5 * time.Second,
 		ExportTimeout:        10 * time.Second,
 		ResourceAttributes:   make(map[string]string),
 	}
@@ -130,6 +321,144 @@ func (c *Config) loadFromEnv() {
 		}
 	}
 
+	// PrometheusGatherers - se vazio, tenta ENV
+	if len(c.PrometheusGatherers) == 0 {
+		if val := os.Getenv("GRAFTEL_PROMETHEUS_BRIDGE"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil && enabled {
+				c.PrometheusGatherers = []promclient.Gatherer{promclient.DefaultGatherer}
+			}
+		}
+	}
+
+	// RuntimeMetrics - se false (padrão), tenta ENV
+	if !c.RuntimeMetrics {
+		if val := os.Getenv("GRAFTEL_RUNTIME_METRICS"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil {
+				c.RuntimeMetrics = enabled
+			}
+		}
+	}
+
+	// HostMetrics - se false (padrão), tenta ENV
+	if !c.HostMetrics {
+		if val := os.Getenv("GRAFTEL_HOST_METRICS"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil {
+				c.HostMetrics = enabled
+			}
+		}
+	}
+
+	// HostMetricsCacheTTL - se zero, tenta ENV
+	if c.HostMetricsCacheTTL == 0 {
+		if val := os.Getenv("GRAFTEL_HOST_METRICS_CACHE_TTL"); val != "" {
+			if duration, err := time.ParseDuration(val); err == nil {
+				c.HostMetricsCacheTTL = duration
+			}
+		}
+	}
+
+	// Debug - se false (padrão), tenta ENV
+	if !c.Debug {
+		if val := os.Getenv("GRAFTEL_DEBUG"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil {
+				c.Debug = enabled
+			}
+		}
+	}
+
+	// DebugBufferSize - se zero, tenta ENV
+	if c.DebugBufferSize == 0 {
+		if val := os.Getenv("GRAFTEL_DEBUG_BUFFER_SIZE"); val != "" {
+			if size, err := strconv.Atoi(val); err == nil {
+				c.DebugBufferSize = size
+			}
+		}
+	}
+
+	// LogLevel e LogLevelOverrides - se não configurados, tentam ENV
+	if c.LogLevel == LogLevelTrace && len(c.LogLevelOverrides) == 0 {
+		if val := os.Getenv("GRAFTEL_LOG_LEVEL"); val != "" {
+			if level, overrides, err := parseLogLevels(val); err == nil {
+				c.LogLevel = level
+				c.LogLevelOverrides = overrides
+			}
+		}
+	}
+
+	// DisableFatalExit - se false (padrão), tenta ENV
+	if !c.DisableFatalExit {
+		if val := os.Getenv("GRAFTEL_DISABLE_FATAL_EXIT"); val != "" {
+			if disabled, err := strconv.ParseBool(val); err == nil {
+				c.DisableFatalExit = disabled
+			}
+		}
+	}
+
+	// FatalExitCode - se zero, tenta ENV
+	if c.FatalExitCode == 0 {
+		if val := os.Getenv("GRAFTEL_FATAL_EXIT_CODE"); val != "" {
+			if code, err := strconv.Atoi(val); err == nil {
+				c.FatalExitCode = code
+			}
+		}
+	}
+
+	// FatalFlushTimeout - se zero, tenta ENV
+	if c.FatalFlushTimeout == 0 {
+		if val := os.Getenv("GRAFTEL_FATAL_FLUSH_TIMEOUT"); val != "" {
+			if timeout, err := time.ParseDuration(val); err == nil {
+				c.FatalFlushTimeout = timeout
+			}
+		}
+	}
+
+	// MetricTemporality - se cumulativa (padrão), tenta ENV
+	if c.MetricTemporality == TemporalityCumulative {
+		if val := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE"); val != "" {
+			if temporality, err := ParseMetricTemporality(val); err == nil {
+				c.MetricTemporality = temporality
+			}
+		}
+	}
+
+	// ExemplarFilter - se trace_based (padrão), tenta ENV
+	if c.ExemplarFilter == ExemplarFilterTraceBased {
+		if val := os.Getenv("OTEL_METRICS_EXEMPLAR_FILTER"); val != "" {
+			if filter, err := ParseExemplarFilter(val); err == nil {
+				c.ExemplarFilter = filter
+			}
+		}
+	}
+
+	// MetricContextTags - se vazio, tenta ENV
+	if len(c.MetricContextTags) == 0 {
+		if val := os.Getenv("GRAFTEL_METRIC_CONTEXT_TAGS"); val != "" {
+			for _, key := range strings.Split(val, ",") {
+				if key = strings.TrimSpace(key); key != "" {
+					c.MetricContextTags = append(c.MetricContextTags, key)
+				}
+			}
+		}
+	}
+
+	// StrictMetricSchemas - se false (padrão), tenta ENV
+	if !c.StrictMetricSchemas {
+		if val := os.Getenv("GRAFTEL_STRICT_METRIC_SCHEMAS"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil {
+				c.StrictMetricSchemas = enabled
+			}
+		}
+	}
+
+	// MetricCardinalityLimit - se zero, tenta ENV
+	if c.MetricCardinalityLimit == 0 {
+		if val := os.Getenv("GRAFTEL_METRIC_CARDINALITY_LIMIT"); val != "" {
+			if limit, err := strconv.Atoi(val); err == nil {
+				c.MetricCardinalityLimit = limit
+			}
+		}
+	}
+
 	// MetricExportInterval - se zero ou padrão, tenta ENV
 	if c.MetricExportInterval == 0 || c.MetricExportInterval == 30*time.Second {
 		if val := os.Getenv("GRAFTEL_METRIC_EXPORT_INTERVAL"); val != "" {
@@ -156,6 +485,41 @@ func (c *Config) loadFromEnv() {
 		}
 	}
 
+	// TraceExportInterval - se zero ou padrão, tenta ENV
+	if c.TraceExportInterval == 0 || c.TraceExportInterval == 5*time.Second {
+		if val := os.Getenv("GRAFTEL_TRACE_EXPORT_INTERVAL"); val != "" {
+			if duration, err := time.ParseDuration(val); err == nil {
+				c.TraceExportInterval = duration
+			}
+		}
+		if c.TraceExportInterval == 0 {
+			c.TraceExportInterval = 5 * time.Second
+		}
+	}
+
+	// LogBatch e TraceBatch - campos vazios tentam ENV
+	c.StatsD.loadFromEnv()
+	c.Push.loadFromEnv()
+	c.Console.loadFromEnv()
+	c.LogBatch.loadFromEnv("GRAFTEL_LOG")
+	c.TraceBatch.loadFromEnv("GRAFTEL_TRACE")
+
+	// PersistentQueueDir - se vazio, tenta ENV
+	if c.PersistentQueueDir == "" {
+		if val := os.Getenv("GRAFTEL_PERSISTENT_QUEUE_DIR"); val != "" {
+			c.PersistentQueueDir = val
+		}
+	}
+
+	// PersistentQueueMaxBytes - se zero, tenta ENV
+	if c.PersistentQueueMaxBytes == 0 {
+		if val := os.Getenv("GRAFTEL_PERSISTENT_QUEUE_MAX_BYTES"); val != "" {
+			if maxBytes, err := strconv.ParseInt(val, 10, 64); err == nil {
+				c.PersistentQueueMaxBytes = maxBytes
+			}
+		}
+	}
+
 	// ExportTimeout - se zero ou padrão, tenta ENV
 	if c.ExportTimeout == 0 || c.ExportTimeout == 10*time.Second {
 		if val := os.Getenv("GRAFTEL_EXPORT_TIMEOUT"); val != "" {
@@ -170,6 +534,59 @@ func (c *Config) loadFromEnv() {
 	}
 }
 
+// loadFromEnv carrega os campos vazios de variáveis <prefix>_MAX_QUEUE_SIZE,
+// <prefix>_MAX_EXPORT_BATCH_SIZE, <prefix>_BATCH_EXPORT_TIMEOUT e <prefix>_SYNCHRONOUS.
+func (b *BatchConfig) loadFromEnv(prefix string) {
+	if b.MaxQueueSize == 0 {
+		if val := os.Getenv(prefix + "_MAX_QUEUE_SIZE"); val != "" {
+			if size, err := strconv.Atoi(val); err == nil {
+				b.MaxQueueSize = size
+			}
+		}
+	}
+
+	if b.MaxExportBatchSize == 0 {
+		if val := os.Getenv(prefix + "_MAX_EXPORT_BATCH_SIZE"); val != "" {
+			if size, err := strconv.Atoi(val); err == nil {
+				b.MaxExportBatchSize = size
+			}
+		}
+	}
+
+	if b.ExportTimeout == 0 {
+		if val := os.Getenv(prefix + "_BATCH_EXPORT_TIMEOUT"); val != "" {
+			if duration, err := time.ParseDuration(val); err == nil {
+				b.ExportTimeout = duration
+			}
+		}
+	}
+
+	if !b.Synchronous {
+		if val := os.Getenv(prefix + "_SYNCHRONOUS"); val != "" {
+			if synchronous, err := strconv.ParseBool(val); err == nil {
+				b.Synchronous = synchronous
+			}
+		}
+	}
+}
+
+// validate verifica os limites do lote; field identifica o campo no erro.
+func (b BatchConfig) validate(field string) error {
+	if b.MaxQueueSize < 0 {
+		return &ErrInvalidConfig{Field: field + ".MaxQueueSize", Message: "não pode ser negativo"}
+	}
+	if b.MaxExportBatchSize < 0 {
+		return &ErrInvalidConfig{Field: field + ".MaxExportBatchSize", Message: "não pode ser negativo"}
+	}
+	if b.ExportTimeout < 0 {
+		return &ErrInvalidConfig{Field: field + ".ExportTimeout", Message: "não pode ser negativo"}
+	}
+	if b.MaxQueueSize > 0 && b.MaxExportBatchSize > b.MaxQueueSize {
+		return &ErrInvalidConfig{Field: field + ".MaxExportBatchSize", Message: "não pode ser maior que MaxQueueSize"}
+	}
+	return nil
+}
+
 // Validate valida a configuração e retorna um erro se inválida.
 // Define valores padrão se não foram configurados.
 func (c *Config) Validate() error {
@@ -185,6 +602,43 @@ func (c *Config) Validate() error {
 		c.MetricExportInterval = 30 * time.Second
 	}
 
+	if c.HostMetricsCacheTTL < 0 {
+		return &ErrInvalidConfig{Field: "HostMetricsCacheTTL", Message: "não pode ser negativo"}
+	}
+	if c.HostMetricsCacheTTL == 0 {
+		c.HostMetricsCacheTTL = c.MetricExportInterval
+	}
+
+	if c.DebugBufferSize < 0 {
+		return &ErrInvalidConfig{Field: "DebugBufferSize", Message: "não pode ser negativo"}
+	}
+	if c.DebugBufferSize == 0 {
+		c.DebugBufferSize = DefaultDebugBufferSize
+	}
+
+	if !c.LogLevel.valid() {
+		return &ErrInvalidConfig{Field: "LogLevel", Message: fmt.Sprintf("nível inválido: %d", c.LogLevel)}
+	}
+	for name, level := range c.LogLevelOverrides {
+		if !level.valid() {
+			return &ErrInvalidConfig{Field: "LogLevelOverrides", Message: fmt.Sprintf("nível inválido para %q: %d", name, level)}
+		}
+	}
+
+	if c.FatalExitCode < 0 {
+		return &ErrInvalidConfig{Field: "FatalExitCode", Message: "não pode ser negativo"}
+	}
+	if c.FatalExitCode == 0 {
+		c.FatalExitCode = 1
+	}
+
+	if c.FatalFlushTimeout < 0 {
+		return &ErrInvalidConfig{Field: "FatalFlushTimeout", Message: "não pode ser negativo"}
+	}
+	if c.FatalFlushTimeout == 0 {
+		c.FatalFlushTimeout = 5 * time.Second
+	}
+
 	if c.LogExportInterval == 0 {
 		c.LogExportInterval = 30 * time.Second
 	}
@@ -193,6 +647,84 @@ func (c *Config) Validate() error {
 		c.ExportTimeout = 10 * time.Second
 	}
 
+	if c.TraceExportInterval == 0 {
+		c.TraceExportInterval = 5 * time.Second
+	}
+
+	if c.MetricTemporality < TemporalityCumulative || c.MetricTemporality > TemporalityLowMemory {
+		return &ErrInvalidConfig{Field: "MetricTemporality", Message: "valor desconhecido"}
+	}
+
+	for kind, temporality := range c.MetricTemporalityOverrides {
+		if temporality != metricdata.CumulativeTemporality && temporality != metricdata.DeltaTemporality {
+			return &ErrInvalidConfig{
+				Field:   "MetricTemporalityOverrides",
+				Message: fmt.Sprintf("temporalidade inválida para %s", kind),
+			}
+		}
+	}
+
+	if c.ExemplarFilter < ExemplarFilterTraceBased || c.ExemplarFilter > ExemplarFilterAlwaysOff {
+		return &ErrInvalidConfig{Field: "ExemplarFilter", Message: "valor desconhecido"}
+	}
+
+	if c.MetricCardinalityLimit < 0 {
+		return &ErrInvalidConfig{Field: "MetricCardinalityLimit", Message: "não pode ser negativo"}
+	}
+
+	for name, limit := range c.MetricCardinalityLimits {
+		if limit < 0 {
+			return &ErrInvalidConfig{
+				Field:   "MetricCardinalityLimits",
+				Message: fmt.Sprintf("limite negativo para '%s'", name),
+			}
+		}
+	}
+
+	for _, view := range c.MetricViews {
+		if err := view.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.StatsD.Endpoint != "" {
+		if c.PrometheusEndpoint != "" {
+			return &ErrInvalidConfig{Field: "StatsD.Endpoint", Message: "não pode ser combinado com PrometheusEndpoint"}
+		}
+		if err := c.StatsD.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Push.Endpoint != "" {
+		if c.PrometheusEndpoint != "" || c.StatsD.Endpoint != "" {
+			return &ErrInvalidConfig{Field: "Push.Endpoint", Message: "não pode ser combinado com PrometheusEndpoint ou StatsD"}
+		}
+		if err := c.Push.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Console.validate(); err != nil {
+		return err
+	}
+
+	if err := c.LogBatch.validate("LogBatch"); err != nil {
+		return err
+	}
+
+	if err := c.TraceBatch.validate("TraceBatch"); err != nil {
+		return err
+	}
+
+	if c.PersistentQueueMaxBytes < 0 {
+		return &ErrInvalidConfig{Field: "PersistentQueueMaxBytes", Message: "não pode ser negativo"}
+	}
+
+	if c.PersistentQueueDir != "" && c.PersistentQueueMaxBytes == 0 {
+		c.PersistentQueueMaxBytes = defaultPersistentQueueMaxBytes
+	}
+
 	return nil
 }
 
@@ -257,18 +789,181 @@ func (c Config) WithMetricExportInterval(interval time.Duration) Config {
 	return c
 }
 
+// WithHostMetrics habilita as métricas de host (Linux). A cada coleta do reader, /proc
+// é lido novamente apenas se a última leitura tiver mais de cacheTTL; um cacheTTL zero
+// usa MetricExportInterval.
+func (c Config) WithHostMetrics(cacheTTL time.Duration) Config {
+	c.HostMetrics = true
+	c.HostMetricsCacheTTL = cacheTTL
+	return c
+}
+
+// WithRuntimeMetrics habilita as métricas automáticas de runtime Go e do processo.
+func (c Config) WithRuntimeMetrics(enabled bool) Config {
+	c.RuntimeMetrics = enabled
+	return c
+}
+
+// WithMetricTemporality define a preferência de temporalidade das métricas exportadas via OTLP.
+func (c Config) WithMetricTemporality(temporality MetricTemporality) Config {
+	c.MetricTemporality = temporality
+	return c
+}
+
+// WithMetricTemporalityOverride força a temporalidade de um tipo de instrumento,
+// independentemente da preferência global.
+func (c Config) WithMetricTemporalityOverride(kind sdkmetric.InstrumentKind, temporality metricdata.Temporality) Config {
+	overrides := make(map[sdkmetric.InstrumentKind]metricdata.Temporality, len(c.MetricTemporalityOverrides)+1)
+	for k, v := range c.MetricTemporalityOverrides {
+		overrides[k] = v
+	}
+	overrides[kind] = temporality
+	c.MetricTemporalityOverrides = overrides
+	return c
+}
+
+// WithMetricCardinalityLimit define o limite de cardinalidade padrão dos instrumentos.
+func (c Config) WithMetricCardinalityLimit(limit int) Config {
+	c.MetricCardinalityLimit = limit
+	return c
+}
+
+// WithInstrumentCardinalityLimit define o limite de cardinalidade de um instrumento específico.
+func (c Config) WithInstrumentCardinalityLimit(instrumentName string, limit int) Config {
+	limits := make(map[string]int, len(c.MetricCardinalityLimits)+1)
+	for k, v := range c.MetricCardinalityLimits {
+		limits[k] = v
+	}
+	limits[instrumentName] = limit
+	c.MetricCardinalityLimits = limits
+	return c
+}
+
+// WithMetricContextTags anexa as tags do contexto com as chaves informadas às medições
+// dos helpers criados por Client.NewMetricsHelper.
+func (c Config) WithMetricContextTags(keys ...string) Config {
+	c.MetricContextTags = append(append([]string(nil), c.MetricContextTags...), keys...)
+	return c
+}
+
+// WithStrictMetricSchemas faz violações de schema causarem panic em vez de descartar a medição.
+func (c Config) WithStrictMetricSchemas(enabled bool) Config {
+	c.StrictMetricSchemas = enabled
+	return c
+}
+
+// WithErrorHandler define o handler de erros e avisos do OpenTelemetry e do graftel.
+func (c Config) WithErrorHandler(handler func(error)) Config {
+	c.ErrorHandler = handler
+	return c
+}
+
+// WithLogLevel define o nível mínimo dos logs.
+func (c Config) WithLogLevel(level LogLevel) Config {
+	c.LogLevel = level
+	return c
+}
+
+// WithLoggerLevel define o nível mínimo dos logs do logger name e dos nomes abaixo dele
+// (ex: "billing" vale para "billing/http").
+func (c Config) WithLoggerLevel(name string, level LogLevel) Config {
+	overrides := make(map[string]LogLevel, len(c.LogLevelOverrides)+1)
+	for k, v := range c.LogLevelOverrides {
+		overrides[k] = v
+	}
+	overrides[name] = level
+	c.LogLevelOverrides = overrides
+	return c
+}
+
+// loggerLevel retorna o nível mínimo do logger name, usando o override mais específico.
+func (c Config) loggerLevel(name string) LogLevel {
+	level, matched := c.LogLevel, -1
+	for key, override := range c.LogLevelOverrides {
+		if len(key) > matched && (name == key || strings.HasPrefix(name, key+"/")) {
+			level, matched = override, len(key)
+		}
+	}
+	return level
+}
+
+// WithFatalExit define se Fatal e FatalWithFields encerram o processo após o flush dos
+// providers. Use false para apenas emitir o log.
+func (c Config) WithFatalExit(enabled bool) Config {
+	c.DisableFatalExit = !enabled
+	return c
+}
+
+// WithFatalExitCode define o código de saída do processo após um log Fatal.
+func (c Config) WithFatalExitCode(code int) Config {
+	c.FatalExitCode = code
+	return c
+}
+
+// WithFatalFlushTimeout define o tempo máximo de flush dos providers após um log Fatal.
+func (c Config) WithFatalFlushTimeout(timeout time.Duration) Config {
+	c.FatalFlushTimeout = timeout
+	return c
+}
+
+// WithExitFunc substitui os.Exit no encerramento após um log Fatal (útil em testes).
+func (c Config) WithExitFunc(exit func(code int)) Config {
+	c.ExitFunc = exit
+	return c
+}
+
 // WithLogExportInterval define o intervalo de exportação de logs.
 func (c Config) WithLogExportInterval(interval time.Duration) Config {
 	c.LogExportInterval = interval
 	return c
 }
 
+// WithTraceExportInterval define o intervalo de exportação de traces.
+func (c Config) WithTraceExportInterval(interval time.Duration) Config {
+	c.TraceExportInterval = interval
+	return c
+}
+
+// WithLogBatch ajusta o processamento em lote de logs.
+func (c Config) WithLogBatch(batch BatchConfig) Config {
+	c.LogBatch = batch
+	return c
+}
+
+// WithLogExporter envia os logs para exporter em vez do endpoint OTLP.
+func (c Config) WithLogExporter(exporter sdklog.Exporter) Config {
+	c.LogExporter = exporter
+	return c
+}
+
+// WithTraceBatch ajusta o processamento em lote de traces.
+func (c Config) WithTraceBatch(batch BatchConfig) Config {
+	c.TraceBatch = batch
+	return c
+}
+
+// WithSynchronousExport usa processors simples (síncronos) para logs e traces.
+// Indicado para jobs de curta duração, em que cada item deve ser exportado imediatamente.
+func (c Config) WithSynchronousExport(synchronous bool) Config {
+	c.LogBatch.Synchronous = synchronous
+	c.TraceBatch.Synchronous = synchronous
+	return c
+}
+
 // WithExportTimeout define o timeout para exportação de dados OTLP.
 func (c Config) WithExportTimeout(timeout time.Duration) Config {
 	c.ExportTimeout = timeout
 	return c
 }
 
+// WithPersistentQueue habilita a fila persistente de exportação em dir.
+// maxBytes limita o tamanho em disco da fila de cada sinal; use 0 para o padrão (256 MiB).
+func (c Config) WithPersistentQueue(dir string, maxBytes int64) Config {
+	c.PersistentQueueDir = dir
+	c.PersistentQueueMaxBytes = maxBytes
+	return c
+}
+
 // WithInsecure desabilita TLS (apenas para desenvolvimento local).
 func (c Config) WithInsecure(insecure bool) Config {
 	c.Insecure = insecure
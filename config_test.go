@@ -220,3 +220,167 @@ func TestConfig_WithMethodsOverrideEnv(t *testing.T) {
 		t.Errorf("ServiceVersion = %v, esperado 'method-version' (With* tem prioridade)", config.ServiceVersion)
 	}
 }
+
+func TestConfig_WithPersistentQueue(t *testing.T) {
+	config := NewConfig("test-service").WithPersistentQueue("/tmp/graftel-queue", 0)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() erro = %v", err)
+	}
+	if config.PersistentQueueDir != "/tmp/graftel-queue" {
+		t.Errorf("PersistentQueueDir incorreto: %s", config.PersistentQueueDir)
+	}
+	if config.PersistentQueueMaxBytes != defaultPersistentQueueMaxBytes {
+		t.Errorf("esperado PersistentQueueMaxBytes padrão, obtido %d", config.PersistentQueueMaxBytes)
+	}
+
+	config = NewConfig("test-service").WithPersistentQueue("/tmp/graftel-queue", -1)
+	if err := config.Validate(); err == nil {
+		t.Error("esperado erro para PersistentQueueMaxBytes negativo")
+	}
+}
+
+func TestConfig_BatchFromEnv(t *testing.T) {
+	os.Setenv("GRAFTEL_LOG_MAX_QUEUE_SIZE", "4096")
+	os.Setenv("GRAFTEL_LOG_MAX_EXPORT_BATCH_SIZE", "256")
+	os.Setenv("GRAFTEL_LOG_BATCH_EXPORT_TIMEOUT", "15s")
+	os.Setenv("GRAFTEL_TRACE_SYNCHRONOUS", "true")
+	os.Setenv("GRAFTEL_TRACE_EXPORT_INTERVAL", "2s")
+	defer func() {
+		os.Unsetenv("GRAFTEL_LOG_MAX_QUEUE_SIZE")
+		os.Unsetenv("GRAFTEL_LOG_MAX_EXPORT_BATCH_SIZE")
+		os.Unsetenv("GRAFTEL_LOG_BATCH_EXPORT_TIMEOUT")
+		os.Unsetenv("GRAFTEL_TRACE_SYNCHRONOUS")
+		os.Unsetenv("GRAFTEL_TRACE_EXPORT_INTERVAL")
+	}()
+
+	config := NewConfig("test-service")
+	if config.LogBatch.MaxQueueSize != 4096 {
+		t.Errorf("LogBatch.MaxQueueSize = %d, esperado 4096", config.LogBatch.MaxQueueSize)
+	}
+	if config.LogBatch.MaxExportBatchSize != 256 {
+		t.Errorf("LogBatch.MaxExportBatchSize = %d, esperado 256", config.LogBatch.MaxExportBatchSize)
+	}
+	if config.LogBatch.ExportTimeout != 15*time.Second {
+		t.Errorf("LogBatch.ExportTimeout = %v, esperado 15s", config.LogBatch.ExportTimeout)
+	}
+	if !config.TraceBatch.Synchronous {
+		t.Error("TraceBatch.Synchronous = false, esperado true")
+	}
+	if config.TraceExportInterval != 2*time.Second {
+		t.Errorf("TraceExportInterval = %v, esperado 2s", config.TraceExportInterval)
+	}
+}
+
+func TestConfig_LogLevel(t *testing.T) {
+	config := NewConfig("test-service").
+		WithLogLevel(LogLevelInfo).
+		WithLoggerLevel("billing", LogLevelError).
+		WithLoggerLevel("billing/http", LogLevelWarn)
+
+	tests := []struct {
+		name string
+		want LogLevel
+	}{
+		{"api", LogLevelInfo},
+		{"billing", LogLevelError},
+		{"billing/jobs", LogLevelError},
+		{"billing/http", LogLevelWarn},
+		{"billing/http/client", LogLevelWarn},
+		{"billingx", LogLevelInfo},
+	}
+	for _, tt := range tests {
+		if got := config.loggerLevel(tt.name); got != tt.want {
+			t.Errorf("loggerLevel(%q) = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+
+	// WithLoggerLevel não altera o mapa da Config original
+	base := NewConfig("test-service").WithLoggerLevel("a", LogLevelWarn)
+	_ = base.WithLoggerLevel("b", LogLevelError)
+	if len(base.LogLevelOverrides) != 1 {
+		t.Errorf("LogLevelOverrides = %v, esperado apenas a", base.LogLevelOverrides)
+	}
+
+	invalid := NewConfig("test-service").WithLoggerLevel("a", LogLevel(42))
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate deveria rejeitar nível inválido")
+	}
+}
+
+func TestConfig_LogLevelFromEnv(t *testing.T) {
+	os.Setenv("GRAFTEL_LOG_LEVEL", "warn,billing/http=debug")
+	defer os.Unsetenv("GRAFTEL_LOG_LEVEL")
+
+	config := NewConfig("test-service")
+	if config.LogLevel != LogLevelWarn {
+		t.Errorf("LogLevel = %v, esperado WARN", config.LogLevel)
+	}
+	if config.LogLevelOverrides["billing/http"] != LogLevelDebug {
+		t.Errorf("LogLevelOverrides = %v", config.LogLevelOverrides)
+	}
+}
+
+func TestConfig_FatalExit(t *testing.T) {
+	config := NewConfig("test-service")
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if config.DisableFatalExit || config.FatalExitCode != 1 || config.FatalFlushTimeout != 5*time.Second {
+		t.Errorf("padrões = disable:%v code:%d timeout:%v", config.DisableFatalExit, config.FatalExitCode, config.FatalFlushTimeout)
+	}
+
+	invalid := NewConfig("test-service").WithFatalExitCode(-1)
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate deveria rejeitar FatalExitCode negativo")
+	}
+	invalid = NewConfig("test-service").WithFatalFlushTimeout(-time.Second)
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate deveria rejeitar FatalFlushTimeout negativo")
+	}
+}
+
+func TestConfig_FatalExitFromEnv(t *testing.T) {
+	os.Setenv("GRAFTEL_DISABLE_FATAL_EXIT", "true")
+	os.Setenv("GRAFTEL_FATAL_EXIT_CODE", "2")
+	os.Setenv("GRAFTEL_FATAL_FLUSH_TIMEOUT", "1s")
+	defer func() {
+		os.Unsetenv("GRAFTEL_DISABLE_FATAL_EXIT")
+		os.Unsetenv("GRAFTEL_FATAL_EXIT_CODE")
+		os.Unsetenv("GRAFTEL_FATAL_FLUSH_TIMEOUT")
+	}()
+
+	config := NewConfig("test-service")
+	if !config.DisableFatalExit || config.FatalExitCode != 2 || config.FatalFlushTimeout != time.Second {
+		t.Errorf("ENV = disable:%v code:%d timeout:%v", config.DisableFatalExit, config.FatalExitCode, config.FatalFlushTimeout)
+	}
+}
+
+func TestConfig_Validate_Batch(t *testing.T) {
+	tests := []struct {
+		name    string
+		batch   BatchConfig
+		wantErr bool
+	}{
+		{"padrão", BatchConfig{}, false},
+		{"válido", BatchConfig{MaxQueueSize: 2048, MaxExportBatchSize: 512}, false},
+		{"lote maior que a fila", BatchConfig{MaxQueueSize: 100, MaxExportBatchSize: 200}, true},
+		{"fila negativa", BatchConfig{MaxQueueSize: -1}, true},
+		{"timeout negativo", BatchConfig{ExportTimeout: -time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig("test-service").WithLogBatch(tt.batch).WithTraceBatch(tt.batch)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() erro = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_WithSynchronousExport(t *testing.T) {
+	config := NewConfig("test-service").WithSynchronousExport(true)
+	if !config.LogBatch.Synchronous || !config.TraceBatch.Synchronous {
+		t.Error("esperado Synchronous=true para logs e traces")
+	}
+}
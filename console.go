@@ -0,0 +1,416 @@
+package graftel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ConsoleFormat define o formato das linhas de log impressas no console.
+type ConsoleFormat int
+
+const (
+	// ConsoleFormatText imprime "msg [chave:valor]...". É o padrão.
+	ConsoleFormatText ConsoleFormat = iota
+	// ConsoleFormatColor imprime o formato texto sempre com o nível, colorido (ANSI).
+	ConsoleFormatColor
+	// ConsoleFormatJSON imprime um objeto JSON por linha.
+	ConsoleFormatJSON
+	// ConsoleFormatLogfmt imprime pares chave=valor no formato logfmt.
+	ConsoleFormatLogfmt
+	// ConsoleFormatOff desabilita a saída no console. Os logs continuam sendo enviados
+	// via OpenTelemetry.
+	ConsoleFormatOff
+)
+
+// String retorna a representação em string do formato, no formato de GRAFTEL_CONSOLE_FORMAT.
+func (f ConsoleFormat) String() string {
+	switch f {
+	case ConsoleFormatText:
+		return "text"
+	case ConsoleFormatColor:
+		return "color"
+	case ConsoleFormatJSON:
+		return "json"
+	case ConsoleFormatLogfmt:
+		return "logfmt"
+	case ConsoleFormatOff:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseConsoleFormat converte "text", "color", "json", "logfmt" ou "off" em ConsoleFormat.
+func ParseConsoleFormat(s string) (ConsoleFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "text":
+		return ConsoleFormatText, nil
+	case "color", "colour":
+		return ConsoleFormatColor, nil
+	case "json":
+		return ConsoleFormatJSON, nil
+	case "logfmt":
+		return ConsoleFormatLogfmt, nil
+	case "off", "none", "false":
+		return ConsoleFormatOff, nil
+	default:
+		return ConsoleFormatText, fmt.Errorf("formato de console inválido: %q", s)
+	}
+}
+
+// ConsoleConfig configura a impressão dos logs no console. O valor zero imprime
+// "msg [chave:valor]... trace_id=... span_id=..." em os.Stderr, com os trace IDs apenas
+// quando há um span no ctx.
+type ConsoleConfig struct {
+	// Format é o formato das linhas. Padrão: ConsoleFormatText.
+	Format ConsoleFormat
+
+	// Writer recebe as linhas. Padrão: os.Stderr.
+	Writer io.Writer
+
+	// TimeFormat é o layout (ver time.Layout) do horário do log. Vazio omite o horário.
+	TimeFormat string
+
+	// Level inclui o nível do log.
+	Level bool
+
+	// LoggerName inclui o nome do logger (o name de Client.NewLogsHelper).
+	LoggerName bool
+
+	// OmitTraceIDs remove trace_id e span_id do span no ctx, incluídos por padrão para
+	// correlacionar as linhas com o trace.
+	OmitTraceIDs bool
+}
+
+// loadFromEnv carrega os campos vazios de GRAFTEL_CONSOLE_FORMAT, GRAFTEL_CONSOLE_OUTPUT
+// ("stdout" ou "stderr"), GRAFTEL_CONSOLE_TIME_FORMAT, GRAFTEL_CONSOLE_FIELDS (lista
+// separada por vírgula com "level" e "logger") e GRAFTEL_CONSOLE_TRACE_IDS.
+func (c *ConsoleConfig) loadFromEnv() {
+	if c.Format == ConsoleFormatText {
+		if val := os.Getenv("GRAFTEL_CONSOLE_FORMAT"); val != "" {
+			if format, err := ParseConsoleFormat(val); err == nil {
+				c.Format = format
+			}
+		}
+	}
+
+	if c.Writer == nil {
+		switch strings.ToLower(strings.TrimSpace(os.Getenv("GRAFTEL_CONSOLE_OUTPUT"))) {
+		case "stdout":
+			c.Writer = os.Stdout
+		case "stderr":
+			c.Writer = os.Stderr
+		}
+	}
+
+	if c.TimeFormat == "" {
+		c.TimeFormat = os.Getenv("GRAFTEL_CONSOLE_TIME_FORMAT")
+	}
+
+	if !c.Level && !c.LoggerName {
+		for _, field := range strings.Split(os.Getenv("GRAFTEL_CONSOLE_FIELDS"), ",") {
+			switch strings.ToLower(strings.TrimSpace(field)) {
+			case "level":
+				c.Level = true
+			case "logger":
+				c.LoggerName = true
+			}
+		}
+	}
+
+	if !c.OmitTraceIDs {
+		if val := os.Getenv("GRAFTEL_CONSOLE_TRACE_IDS"); val != "" {
+			if enabled, err := strconv.ParseBool(val); err == nil {
+				c.OmitTraceIDs = !enabled
+			}
+		}
+	}
+}
+
+// validate verifica a configuração do console.
+func (c ConsoleConfig) validate() error {
+	if c.Format < ConsoleFormatText || c.Format > ConsoleFormatOff {
+		return &ErrInvalidConfig{Field: "Console.Format", Message: "valor desconhecido"}
+	}
+	return nil
+}
+
+// WithConsole define o formato, o destino e os campos dos logs impressos no console.
+func (c Config) WithConsole(console ConsoleConfig) Config {
+	c.Console = console
+	return c
+}
+
+// defaultConsole é usado pelos LogsHelper criados fora de um Client.
+var defaultConsole = newConsoleWriter(ConsoleConfig{})
+
+// consoleWriter imprime os logs no console conforme o ConsoleConfig. As escritas são
+// serializadas para que as linhas de goroutines diferentes não se misturem.
+type consoleWriter struct {
+	config ConsoleConfig
+	mu     sync.Mutex
+}
+
+func newConsoleWriter(config ConsoleConfig) *consoleWriter {
+	if config.Writer == nil {
+		config.Writer = os.Stderr
+	}
+	return &consoleWriter{config: config}
+}
+
+// consoleLine reúne os dados de um log para formatação.
+type consoleLine struct {
+	time       time.Time
+	level      LogLevel
+	logger     string
+	msg        string
+	tags       string
+	attrs      []otellog.KeyValue
+	span       trace.SpanContext
+	stacktrace string
+}
+
+// print formata e escreve um log. tagsStr é usado pelos formatos texto; os formatos
+// estruturados usam os atributos do record, sem o prefixo "tags.".
+func (w *consoleWriter) print(ctx context.Context, level LogLevel, logger string, record otellog.Record, msg, tagsStr string) {
+	if w == nil {
+		w = defaultConsole
+	}
+	if w.config.Format == ConsoleFormatOff {
+		return
+	}
+
+	line := consoleLine{level: level, logger: logger, msg: msg, tags: tagsStr}
+	if w.config.TimeFormat != "" {
+		line.time = record.Timestamp()
+		if line.time.IsZero() {
+			line.time = time.Now()
+		}
+	}
+	if !w.config.OmitTraceIDs {
+		line.span = trace.SpanContextFromContext(ctx)
+	}
+	if level == LogLevelError || level == LogLevelFatal {
+		line.stacktrace = getStackTrace()
+	}
+
+	var buf bytes.Buffer
+	switch w.config.Format {
+	case ConsoleFormatJSON:
+		record.WalkAttributes(func(kv otellog.KeyValue) bool {
+			line.attrs = append(line.attrs, kv)
+			return true
+		})
+		w.writeJSON(&buf, line)
+	case ConsoleFormatLogfmt:
+		record.WalkAttributes(func(kv otellog.KeyValue) bool {
+			line.attrs = append(line.attrs, kv)
+			return true
+		})
+		w.writeLogfmt(&buf, line)
+	default:
+		w.writeText(&buf, line)
+	}
+	buf.WriteByte('\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.config.Writer.Write(buf.Bytes())
+}
+
+// consoleColors são as cores ANSI de cada nível no ConsoleFormatColor.
+var consoleColors = map[LogLevel]string{
+	LogLevelTrace: "\x1b[90m",
+	LogLevelDebug: "\x1b[36m",
+	LogLevelInfo:  "\x1b[32m",
+	LogLevelWarn:  "\x1b[33m",
+	LogLevelError: "\x1b[31m",
+	LogLevelFatal: "\x1b[35m",
+}
+
+// writeText escreve "[horário] NÍVEL logger msg [chave:valor]... trace_id=... stacktrace".
+func (w *consoleWriter) writeText(buf *bytes.Buffer, line consoleLine) {
+	parts := make([]string, 0, 7)
+	if !line.time.IsZero() {
+		parts = append(parts, line.time.Format(w.config.TimeFormat))
+	}
+	if w.config.Level || w.config.Format == ConsoleFormatColor {
+		level := fmt.Sprintf("%-5s", line.level)
+		if w.config.Format == ConsoleFormatColor {
+			level = consoleColors[line.level] + level + "\x1b[0m"
+		}
+		parts = append(parts, level)
+	}
+	if w.config.LoggerName && line.logger != "" {
+		parts = append(parts, line.logger)
+	}
+	parts = append(parts, line.msg)
+	if line.tags != "" {
+		parts = append(parts, line.tags)
+	}
+	if line.span.IsValid() {
+		parts = append(parts, "trace_id="+line.span.TraceID().String(), "span_id="+line.span.SpanID().String())
+	}
+	if line.stacktrace != "" {
+		parts = append(parts, line.stacktrace)
+	}
+	buf.WriteString(strings.Join(parts, " "))
+}
+
+// writeJSON escreve um objeto JSON com os campos fixos seguidos dos atributos.
+func (w *consoleWriter) writeJSON(buf *bytes.Buffer, line consoleLine) {
+	first := true
+	field := func(key string, value interface{}) {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded, _ = json.Marshal(fmt.Sprint(value))
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		keyJSON, _ := json.Marshal(key)
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(encoded)
+	}
+
+	buf.WriteByte('{')
+	if !line.time.IsZero() {
+		field("time", line.time.Format(w.config.TimeFormat))
+	}
+	if w.config.Level {
+		field("level", line.level.String())
+	}
+	if w.config.LoggerName && line.logger != "" {
+		field("logger", line.logger)
+	}
+	field("msg", line.msg)
+	if line.span.IsValid() {
+		field("trace_id", line.span.TraceID().String())
+		field("span_id", line.span.SpanID().String())
+		field("trace_flags", line.span.TraceFlags().String())
+	}
+	for _, kv := range line.attrs {
+		field(consoleKey(kv.Key), consoleValue(kv.Value))
+	}
+	if line.stacktrace != "" {
+		field("stacktrace", line.stacktrace)
+	}
+	buf.WriteByte('}')
+}
+
+// writeLogfmt escreve pares chave=valor. Mapas viram chaves com ponto (ex: customer.id).
+func (w *consoleWriter) writeLogfmt(buf *bytes.Buffer, line consoleLine) {
+	pair := func(key, value string) {
+		if buf.Len() > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(logfmtValue(value))
+	}
+
+	if !line.time.IsZero() {
+		pair("time", line.time.Format(w.config.TimeFormat))
+	}
+	if w.config.Level {
+		pair("level", strings.ToLower(line.level.String()))
+	}
+	if w.config.LoggerName && line.logger != "" {
+		pair("logger", line.logger)
+	}
+	pair("msg", line.msg)
+	if line.span.IsValid() {
+		pair("trace_id", line.span.TraceID().String())
+		pair("span_id", line.span.SpanID().String())
+		pair("trace_flags", line.span.TraceFlags().String())
+	}
+
+	var walk func(prefix string, v otellog.Value)
+	walk = func(prefix string, v otellog.Value) {
+		if v.Kind() != otellog.KindMap {
+			pair(prefix, fmt.Sprint(consoleValue(v)))
+			return
+		}
+		for _, kv := range v.AsMap() {
+			walk(prefix+"."+kv.Key, kv.Value)
+		}
+	}
+	for _, kv := range line.attrs {
+		walk(consoleKey(kv.Key), kv.Value)
+	}
+	if line.stacktrace != "" {
+		pair("stacktrace", line.stacktrace)
+	}
+}
+
+// consoleReservedKeys são as chaves escritas pelo próprio console nos formatos JSON e
+// logfmt.
+var consoleReservedKeys = map[string]bool{
+	"time": true, "level": true, "logger": true, "msg": true,
+	"trace_id": true, "span_id": true, "trace_flags": true, "stacktrace": true,
+}
+
+// consoleKey remove o prefixo "tags." da chave do atributo, exceto quando o resultado
+// colide com uma chave reservada (ex: uma tag msg vira tags.msg), evitando chaves
+// duplicadas no JSON.
+func consoleKey(key string) string {
+	if trimmed := strings.TrimPrefix(key, "tags."); !consoleReservedKeys[trimmed] {
+		return trimmed
+	}
+	if strings.HasPrefix(key, "tags.") {
+		return key
+	}
+	return "tags." + key
+}
+
+// logfmtValue coloca o valor entre aspas quando ele é vazio ou contém espaços, aspas,
+// '=' ou caracteres de controle.
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// consoleValue converte um otellog.Value em um valor Go serializável.
+func consoleValue(v otellog.Value) interface{} {
+	switch v.Kind() {
+	case otellog.KindBool:
+		return v.AsBool()
+	case otellog.KindInt64:
+		return v.AsInt64()
+	case otellog.KindFloat64:
+		return v.AsFloat64()
+	case otellog.KindString:
+		return v.AsString()
+	case otellog.KindBytes:
+		return v.AsBytes()
+	case otellog.KindSlice:
+		values := make([]interface{}, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			values = append(values, consoleValue(item))
+		}
+		return values
+	case otellog.KindMap:
+		m := make(map[string]interface{}, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			m[kv.Key] = consoleValue(kv.Value)
+		}
+		return m
+	default:
+		return nil
+	}
+}
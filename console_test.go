@@ -0,0 +1,232 @@
+package graftel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newConsoleHelper cria um LogsHelper "billing/http" cuja saída de console vai para o
+// buffer retornado.
+func newConsoleHelper(console ConsoleConfig) (*logsHelper, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	console.Writer = buf
+	return &logsHelper{logger: createTestLogger(), name: "billing/http", console: newConsoleWriter(console)}, buf
+}
+
+func TestConsole_TextDefault(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{})
+	helper.Info(context.Background(), "pedido criado", attribute.Int("order_id", 42))
+
+	if got := buf.String(); got != "pedido criado [order_id:42]\n" {
+		t.Errorf("linha = %q", got)
+	}
+}
+
+func TestConsole_TextWithFields(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{
+		TimeFormat: time.RFC3339,
+		Level:      true,
+		LoggerName: true,
+	})
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	helper.Warn(ctx, "lento", attribute.String("rota", "/pagar"))
+
+	fields := strings.Fields(buf.String())
+	if len(fields) != 7 {
+		t.Fatalf("linha = %q", buf.String())
+	}
+	if _, err := time.Parse(time.RFC3339, fields[0]); err != nil {
+		t.Errorf("horário = %q: %v", fields[0], err)
+	}
+	if fields[1] != "WARN" || fields[2] != "billing/http" || fields[3] != "lento" || fields[4] != "[rota:/pagar]" {
+		t.Errorf("linha = %q", buf.String())
+	}
+	if fields[5] != "trace_id="+span.SpanContext().TraceID().String() || fields[6] != "span_id="+span.SpanContext().SpanID().String() {
+		t.Errorf("trace IDs = %v", fields[5:])
+	}
+}
+
+func TestConsole_Color(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatColor})
+	helper.Info(context.Background(), "colorido")
+
+	if got := buf.String(); got != "\x1b[32mINFO \x1b[0m colorido\n" {
+		t.Errorf("linha = %q", got)
+	}
+}
+
+func TestConsole_JSON(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatJSON, Level: true, LoggerName: true})
+	helper.Info(context.Background(), "pedido criado",
+		attribute.Int("order_id", 42),
+		attribute.Bool("vip", true),
+		attribute.StringSlice("itens", []string{"a", "b"}),
+	)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("linha não é JSON: %q: %v", buf.String(), err)
+	}
+	if line["level"] != "INFO" || line["logger"] != "billing/http" || line["msg"] != "pedido criado" {
+		t.Errorf("campos = %v", line)
+	}
+	if line["order_id"] != float64(42) || line["vip"] != true || len(line["itens"].([]interface{})) != 2 {
+		t.Errorf("atributos = %v", line)
+	}
+	if _, ok := line["time"]; ok {
+		t.Error("time deveria ser omitido sem TimeFormat")
+	}
+}
+
+func TestConsole_JSONStacktrace(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatJSON})
+	helper.Error(context.Background(), "falhou")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("linha não é JSON: %q: %v", buf.String(), err)
+	}
+	if line["stacktrace"] == "" || line["stacktrace"] == nil {
+		t.Errorf("logs de erro deveriam ter stacktrace: %v", line)
+	}
+}
+
+func TestConsole_JSONReservedKeys(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatJSON, Level: true, TimeFormat: time.RFC3339})
+	helper.Info(context.Background(), "pedido criado",
+		attribute.String("msg", "tag"),
+		attribute.String("level", "alto"),
+		attribute.String("time", "ontem"),
+	)
+
+	for _, key := range []string{"msg", "level", "time"} {
+		if n := strings.Count(buf.String(), `"`+key+`":`); n != 1 {
+			t.Errorf("chave %s aparece %d vezes: %s", key, n, buf.String())
+		}
+	}
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("linha não é JSON: %q: %v", buf.String(), err)
+	}
+	if line["msg"] != "pedido criado" || line["level"] != "INFO" {
+		t.Errorf("campos do console foram sobrescritos: %v", line)
+	}
+	if line["tags.msg"] != "tag" || line["tags.level"] != "alto" || line["tags.time"] != "ontem" {
+		t.Errorf("tags com chaves reservadas deveriam manter o prefixo: %v", line)
+	}
+}
+
+func TestConsole_Logfmt(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatLogfmt, Level: true})
+	handler := slog.New(&slogHandler{helper: helper, opts: SlogHandlerOptions{Level: slog.LevelInfo}})
+	handler.Info("pedido criado", "order_id", 42, slog.Group("customer", "id", "c 1"))
+
+	want := `level=info msg="pedido criado" order_id=42 customer.id="c 1"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("linha = %q, esperado %q", got, want)
+	}
+}
+
+func TestConsole_Off(t *testing.T) {
+	helper, buf := newConsoleHelper(ConsoleConfig{Format: ConsoleFormatOff})
+	helper.Error(context.Background(), "silencioso")
+
+	if buf.Len() != 0 {
+		t.Errorf("ConsoleFormatOff não deveria imprimir: %q", buf.String())
+	}
+}
+
+func TestParseConsoleFormat(t *testing.T) {
+	for _, format := range []ConsoleFormat{ConsoleFormatText, ConsoleFormatColor, ConsoleFormatJSON, ConsoleFormatLogfmt, ConsoleFormatOff} {
+		got, err := ParseConsoleFormat(strings.ToUpper(format.String()))
+		if err != nil || got != format {
+			t.Errorf("ParseConsoleFormat(%q) = (%v, %v)", format.String(), got, err)
+		}
+	}
+	if _, err := ParseConsoleFormat("xml"); err == nil {
+		t.Error("ParseConsoleFormat deveria rejeitar formato desconhecido")
+	}
+}
+
+func TestConsoleConfig_LoadFromEnv(t *testing.T) {
+	os.Setenv("GRAFTEL_CONSOLE_FORMAT", "json")
+	os.Setenv("GRAFTEL_CONSOLE_OUTPUT", "stdout")
+	os.Setenv("GRAFTEL_CONSOLE_TIME_FORMAT", time.RFC3339Nano)
+	os.Setenv("GRAFTEL_CONSOLE_FIELDS", "level")
+	os.Setenv("GRAFTEL_CONSOLE_TRACE_IDS", "false")
+	defer func() {
+		os.Unsetenv("GRAFTEL_CONSOLE_FORMAT")
+		os.Unsetenv("GRAFTEL_CONSOLE_OUTPUT")
+		os.Unsetenv("GRAFTEL_CONSOLE_TIME_FORMAT")
+		os.Unsetenv("GRAFTEL_CONSOLE_FIELDS")
+		os.Unsetenv("GRAFTEL_CONSOLE_TRACE_IDS")
+	}()
+
+	config := NewConfig("test-service")
+	console := config.Console
+	if console.Format != ConsoleFormatJSON || console.Writer != os.Stdout || console.TimeFormat != time.RFC3339Nano {
+		t.Errorf("Console = %+v", console)
+	}
+	if !console.Level || console.LoggerName || !console.OmitTraceIDs {
+		t.Errorf("campos = level:%v logger:%v omitTrace:%v", console.Level, console.LoggerName, console.OmitTraceIDs)
+	}
+}
+
+func TestConsole_FromClientConfig(t *testing.T) {
+	buf := &bytes.Buffer{}
+	config := NewConfig("test-service").WithConsole(ConsoleConfig{Writer: buf, LoggerName: true})
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() erro = %v", err)
+	}
+
+	client.NewLogsHelper("api").Info(context.Background(), "ok")
+	if got := buf.String(); got != "api ok\n" {
+		t.Errorf("linha = %q", got)
+	}
+
+	invalid := NewConfig("test-service").WithConsole(ConsoleConfig{Format: ConsoleFormat(9)})
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate deveria rejeitar formato desconhecido")
+	}
+}
+
+func TestConsole_TraceIDs(t *testing.T) {
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	sc := span.SpanContext()
+
+	helper, buf := newConsoleHelper(ConsoleConfig{})
+	helper.Info(ctx, "ok")
+	want := "ok trace_id=" + sc.TraceID().String() + " span_id=" + sc.SpanID().String() + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("linha = %q, esperado %q", got, want)
+	}
+
+	helper, buf = newConsoleHelper(ConsoleConfig{Format: ConsoleFormatJSON})
+	helper.Info(ctx, "ok")
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("linha não é JSON: %q: %v", buf.String(), err)
+	}
+	if line["trace_id"] != sc.TraceID().String() || line["span_id"] != sc.SpanID().String() || line["trace_flags"] != "01" {
+		t.Errorf("trace IDs = %v", line)
+	}
+
+	helper, buf = newConsoleHelper(ConsoleConfig{OmitTraceIDs: true})
+	helper.Info(ctx, "ok")
+	if got := buf.String(); got != "ok\n" {
+		t.Errorf("OmitTraceIDs deveria remover os trace IDs: %q", got)
+	}
+}
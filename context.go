@@ -2,6 +2,8 @@ package graftel
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -33,6 +35,61 @@ func MergeContextTags(ctx context.Context, additionalTags ...attribute.KeyValue)
 	return context.WithValue(ctx, tagsContextKey, mergedTags)
 }
 
+// contextTagFilter seleciona as tags do contexto que são anexadas às medições.
+type contextTagFilter struct {
+	keys map[attribute.Key]struct{}
+	id   string
+}
+
+// newContextTagFilter cria um filtro para keys. Retorna nil quando keys está vazio.
+func newContextTagFilter(keys []string) *contextTagFilter {
+	if len(keys) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	f := &contextTagFilter{
+		keys: make(map[attribute.Key]struct{}, len(keys)),
+		id:   strings.Join(sorted, ","),
+	}
+	for _, k := range keys {
+		f.keys[attribute.Key(k)] = struct{}{}
+	}
+	return f
+}
+
+// String retorna as chaves permitidas, ordenadas e separadas por vírgula.
+func (f *contextTagFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.id
+}
+
+// merge retorna as tags permitidas de ctx seguidas de attrs, para que os atributos
+// explícitos prevaleçam sobre tags com a mesma chave. É seguro chamar em um receptor nil.
+func (f *contextTagFilter) merge(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if f == nil {
+		return attrs
+	}
+	tags := GetTagsFromContext(ctx)
+	if len(tags) == 0 {
+		return attrs
+	}
+
+	merged := make([]attribute.KeyValue, 0, len(tags)+len(attrs))
+	for _, tag := range tags {
+		if _, ok := f.keys[tag.Key]; ok {
+			merged = append(merged, tag)
+		}
+	}
+	if len(merged) == 0 {
+		return attrs
+	}
+	return append(merged, attrs...)
+}
+
 type ContextLogger struct {
 	logger LogsHelper
 	ctx    context.Context
@@ -50,6 +107,12 @@ func (cl *ContextLogger) WithTags(tags ...attribute.KeyValue) *ContextLogger {
 	return cl
 }
 
+// Enabled informa se o logger emitiria um log do nível no contexto do ContextLogger
+// (veja LogEnabled).
+func (cl *ContextLogger) Enabled(level LogLevel) bool {
+	return LogEnabled(cl.ctx, cl.logger, level)
+}
+
 func (cl *ContextLogger) Log(level LogLevel, msg string, tags ...attribute.KeyValue) {
 	ctxTags := GetTagsFromContext(cl.ctx)
 	allTags := append(ctxTags, tags...)
@@ -77,7 +140,9 @@ func (cl *ContextLogger) Error(msg string, tags ...attribute.KeyValue) {
 }
 
 func (cl *ContextLogger) Fatal(msg string, tags ...attribute.KeyValue) {
-	cl.Log(LogLevelFatal, msg, tags...)
+	ctxTags := GetTagsFromContext(cl.ctx)
+	allTags := append(ctxTags, tags...)
+	cl.logger.Fatal(cl.ctx, msg, allTags...)
 }
 
 func (cl *ContextLogger) LogWithFields(level LogLevel, msg string, fields map[string]interface{}, tags ...attribute.KeyValue) {
@@ -2,10 +2,13 @@ package graftel
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 func TestWithTags(t *testing.T) {
@@ -171,3 +174,102 @@ func TestContextLogger_InheritsTags(t *testing.T) {
 
 	ctxLogger.Info("test message")
 }
+
+func TestContextTagFilter_Merge(t *testing.T) {
+	filter := newContextTagFilter([]string{"tenant", "region"})
+	ctx := WithTags(context.Background(),
+		attribute.String("tenant", "acme"),
+		attribute.String("region", "sa-east-1"),
+		attribute.String("user_id", "42"),
+	)
+
+	merged := filter.merge(ctx, []attribute.KeyValue{attribute.String("region", "us-east-1")})
+	set := attribute.NewSet(merged...)
+
+	if set.Len() != 2 {
+		t.Fatalf("esperado tenant e region, obtido %v", set.ToSlice())
+	}
+	if _, ok := set.Value("user_id"); ok {
+		t.Error("user_id não está na allowlist")
+	}
+	if v, _ := set.Value("region"); v.AsString() != "us-east-1" {
+		t.Errorf("atributo explícito deve prevalecer, obtido %s", v.AsString())
+	}
+
+	var nilFilter *contextTagFilter
+	attrs := []attribute.KeyValue{attribute.String("a", "b")}
+	if got := nilFilter.merge(ctx, attrs); len(got) != 1 {
+		t.Errorf("filtro nil deve retornar os atributos sem alteração, obtido %v", got)
+	}
+	if newContextTagFilter(nil) != nil {
+		t.Error("newContextTagFilter() sem chaves deve retornar nil")
+	}
+}
+
+func TestMetricsHelper_WithContextTags(t *testing.T) {
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"),
+		WithContextTags("tenant"),
+	)
+
+	orders, err := helper.NewCounter("orders_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latency, err := helper.NewHistogram("checkout_seconds", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithTags(context.Background(), attribute.String("tenant", "acme"), attribute.String("trace", "x"))
+	orders.Increment(ctx, attribute.String("status", "paid"))
+	latency.Record(ctx, 0.2)
+
+	got := collectMetrics(t, reader)
+	for _, name := range []string{"orders_total", "checkout_seconds"} {
+		var attrs attribute.Set
+		switch data := got[name].Data.(type) {
+		case metricdata.Sum[int64]:
+			attrs = data.DataPoints[0].Attributes
+		case metricdata.Histogram[float64]:
+			attrs = data.DataPoints[0].Attributes
+		}
+		if v, _ := attrs.Value("tenant"); v.AsString() != "acme" {
+			t.Errorf("%s: tag tenant ausente em %v", name, attrs.ToSlice())
+		}
+		if _, ok := attrs.Value("trace"); ok {
+			t.Errorf("%s: tag fora da allowlist anexada", name)
+		}
+	}
+}
+
+func TestClient_MetricContextTagsFromConfig(t *testing.T) {
+	config := NewConfig("test-service").WithInsecure(true).WithMetricContextTags("tenant")
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := client.NewMetricsHelper("orders").NewCounter("orders_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.tags.String() != "tenant" {
+		t.Errorf("allowlist = %q, esperado tenant", first.tags.String())
+	}
+}
+
+func TestMetricsHelper_ContextTagsConflict(t *testing.T) {
+	meter := metric.NewMeterProvider().Meter("test")
+	registry := newInstrumentRegistry()
+	tenant := &metricsHelper{meter: meter, registry: registry, tags: newContextTagFilter([]string{"tenant"})}
+	plain := &metricsHelper{meter: meter, registry: registry}
+
+	if _, err := tenant.NewCounter("orders_total", "test"); err != nil {
+		t.Fatal(err)
+	}
+	var conflict *ErrInstrumentConflict
+	if _, err := plain.NewCounter("orders_total", "test"); !errors.As(err, &conflict) || conflict.Field != "context_tags" {
+		t.Errorf("esperado conflito em context_tags, obtido %v", err)
+	}
+}
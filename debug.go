@@ -0,0 +1,431 @@
+package graftel
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DefaultDebugBufferSize é o número padrão de logs e de spans mantidos em memória pelo DebugHandler.
+const DefaultDebugBufferSize = 100
+
+// DebugHandlerProvider é implementado pelo Client retornado por NewClient. Use uma
+// type assertion para obter o handler sem depender da interface Client:
+//
+//	if p, ok := client.(graftel.DebugHandlerProvider); ok {
+//		mux.Handle("/debug/telemetry", p.DebugHandler())
+//	}
+type DebugHandlerProvider interface {
+	// DebugHandler retorna um http.Handler que mostra as agregações atuais das métricas e
+	// os logs e spans mais recentes, em HTML ou JSON (?format=json), com filtros
+	// ?instrument= e ?span=. Se Config.Debug não estiver habilitado, o handler responde 404.
+	DebugHandler() http.Handler
+}
+
+// WithDebugHandler habilita DebugHandlerProvider.DebugHandler, mantendo em memória os últimos bufferSize
+// logs e spans. Um bufferSize zero usa DefaultDebugBufferSize.
+func (c Config) WithDebugHandler(bufferSize int) Config {
+	c.Debug = true
+	c.DebugBufferSize = bufferSize
+	return c
+}
+
+// debugRing é um buffer circular que mantém os últimos itens adicionados.
+type debugRing[T any] struct {
+	mu    sync.Mutex
+	items []T
+	next  int
+	full  bool
+}
+
+func newDebugRing[T any](size int) *debugRing[T] {
+	return &debugRing[T]{items: make([]T, size)}
+}
+
+func (r *debugRing[T]) add(item T) {
+	r.mu.Lock()
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// snapshot retorna os itens do mais recente para o mais antigo.
+func (r *debugRing[T]) snapshot() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := r.next
+	if r.full {
+		n = len(r.items)
+	}
+	result := make([]T, 0, n)
+	for i := 1; i <= n; i++ {
+		result = append(result, r.items[(r.next-i+len(r.items))%len(r.items)])
+	}
+	return result
+}
+
+// debugLog é um registro de log mantido pelo DebugHandler.
+type debugLog struct {
+	Time       time.Time         `json:"time"`
+	Severity   string            `json:"severity"`
+	Body       string            `json:"body"`
+	Scope      string            `json:"scope"`
+	TraceID    string            `json:"trace_id,omitempty"`
+	SpanID     string            `json:"span_id,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// debugSpan é um span finalizado mantido pelo DebugHandler.
+type debugSpan struct {
+	Name         string            `json:"name"`
+	Scope        string            `json:"scope"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Kind         string            `json:"kind"`
+	Start        time.Time         `json:"start"`
+	Duration     time.Duration     `json:"duration_ns"`
+	Status       string            `json:"status"`
+	StatusDesc   string            `json:"status_description,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// debugMetric é a agregação atual de um instrumento.
+type debugMetric struct {
+	Name        string       `json:"name"`
+	Scope       string       `json:"scope"`
+	Description string       `json:"description,omitempty"`
+	Unit        string       `json:"unit,omitempty"`
+	Type        string       `json:"type"`
+	Points      []debugPoint `json:"points"`
+}
+
+// debugPoint é um ponto de uma métrica: Value para somas e gauges; Count, Sum e
+// buckets para histogramas.
+type debugPoint struct {
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Value        *float64          `json:"value,omitempty"`
+	Count        uint64            `json:"count,omitempty"`
+	Sum          *float64          `json:"sum,omitempty"`
+	Bounds       []float64         `json:"bounds,omitempty"`
+	BucketCounts []uint64          `json:"bucket_counts,omitempty"`
+}
+
+// debugSnapshot é o conteúdo renderizado pelo DebugHandler.
+type debugSnapshot struct {
+	Time    time.Time     `json:"time"`
+	Metrics []debugMetric `json:"metrics"`
+	Logs    []debugLog    `json:"logs"`
+	Spans   []debugSpan   `json:"spans"`
+}
+
+// debugState guarda o reader manual de métricas e os buffers de logs e spans.
+type debugState struct {
+	reader *sdkmetric.ManualReader
+	logs   *debugRing[debugLog]
+	spans  *debugRing[debugSpan]
+}
+
+func newDebugState(bufferSize int, producers ...sdkmetric.Producer) *debugState {
+	var opts []sdkmetric.ManualReaderOption
+	for _, producer := range producers {
+		opts = append(opts, sdkmetric.WithProducer(producer))
+	}
+	return &debugState{
+		reader: sdkmetric.NewManualReader(opts...),
+		logs:   newDebugRing[debugLog](bufferSize),
+		spans:  newDebugRing[debugSpan](bufferSize),
+	}
+}
+
+// debugLogProcessor copia cada log emitido para o buffer do DebugHandler.
+type debugLogProcessor struct {
+	ring *debugRing[debugLog]
+}
+
+func (p debugLogProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	entry := debugLog{
+		Time:     record.Timestamp(),
+		Severity: record.SeverityText(),
+		Body:     record.Body().String(),
+		Scope:    record.InstrumentationScope().Name,
+	}
+	if entry.Time.IsZero() {
+		entry.Time = record.ObservedTimestamp()
+	}
+	if entry.Severity == "" {
+		entry.Severity = record.Severity().String()
+	}
+	if record.TraceID().IsValid() {
+		entry.TraceID = record.TraceID().String()
+		entry.SpanID = record.SpanID().String()
+	}
+	if record.AttributesLen() > 0 {
+		entry.Attributes = make(map[string]string, record.AttributesLen())
+		record.WalkAttributes(func(kv otellog.KeyValue) bool {
+			entry.Attributes[kv.Key] = kv.Value.String()
+			return true
+		})
+	}
+	p.ring.add(entry)
+	return nil
+}
+
+func (debugLogProcessor) Shutdown(context.Context) error   { return nil }
+func (debugLogProcessor) ForceFlush(context.Context) error { return nil }
+
+// debugSpanProcessor copia cada span finalizado para o buffer do DebugHandler.
+type debugSpanProcessor struct {
+	ring *debugRing[debugSpan]
+}
+
+func (debugSpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p debugSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	entry := debugSpan{
+		Name:       s.Name(),
+		Scope:      s.InstrumentationScope().Name,
+		TraceID:    s.SpanContext().TraceID().String(),
+		SpanID:     s.SpanContext().SpanID().String(),
+		Kind:       s.SpanKind().String(),
+		Start:      s.StartTime(),
+		Duration:   s.EndTime().Sub(s.StartTime()),
+		Status:     s.Status().Code.String(),
+		StatusDesc: s.Status().Description,
+		Attributes: debugAttributes(attribute.NewSet(s.Attributes()...)),
+	}
+	if s.Parent().IsValid() {
+		entry.ParentSpanID = s.Parent().SpanID().String()
+	}
+	p.ring.add(entry)
+}
+
+func (debugSpanProcessor) Shutdown(context.Context) error   { return nil }
+func (debugSpanProcessor) ForceFlush(context.Context) error { return nil }
+
+// debugAttributes converte um conjunto de atributos em um mapa de strings.
+func debugAttributes(set attribute.Set) map[string]string {
+	if set.Len() == 0 {
+		return nil
+	}
+	attrs := make(map[string]string, set.Len())
+	iter := set.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+// collectMetrics lê a agregação atual de todos os instrumentos cujo nome contém filter.
+func (d *debugState) collectMetrics(ctx context.Context, filter string) ([]debugMetric, error) {
+	var rm metricdata.ResourceMetrics
+	if err := d.reader.Collect(ctx, &rm); err != nil {
+		return nil, err
+	}
+
+	var metrics []debugMetric
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if !strings.Contains(m.Name, filter) {
+				continue
+			}
+			dm := debugMetric{Name: m.Name, Scope: sm.Scope.Name, Description: m.Description, Unit: m.Unit}
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				dm.Type, dm.Points = "sum", sumPoints(data.DataPoints)
+			case metricdata.Sum[float64]:
+				dm.Type, dm.Points = "sum", sumPoints(data.DataPoints)
+			case metricdata.Gauge[int64]:
+				dm.Type, dm.Points = "gauge", sumPoints(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				dm.Type, dm.Points = "gauge", sumPoints(data.DataPoints)
+			case metricdata.Histogram[int64]:
+				dm.Type, dm.Points = "histogram", histogramPoints(data.DataPoints)
+			case metricdata.Histogram[float64]:
+				dm.Type, dm.Points = "histogram", histogramPoints(data.DataPoints)
+			case metricdata.ExponentialHistogram[int64]:
+				dm.Type, dm.Points = "exponential_histogram", exponentialPoints(data.DataPoints)
+			case metricdata.ExponentialHistogram[float64]:
+				dm.Type, dm.Points = "exponential_histogram", exponentialPoints(data.DataPoints)
+			case metricdata.Summary:
+				dm.Type = "summary"
+				for _, dp := range data.DataPoints {
+					sum := dp.Sum
+					dm.Points = append(dm.Points, debugPoint{Attributes: debugAttributes(dp.Attributes), Count: dp.Count, Sum: &sum})
+				}
+			default:
+				continue
+			}
+			metrics = append(metrics, dm)
+		}
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Name != metrics[j].Name {
+			return metrics[i].Name < metrics[j].Name
+		}
+		return metrics[i].Scope < metrics[j].Scope
+	})
+	return metrics, nil
+}
+
+func sumPoints[N int64 | float64](dps []metricdata.DataPoint[N]) []debugPoint {
+	points := make([]debugPoint, 0, len(dps))
+	for _, dp := range dps {
+		value := float64(dp.Value)
+		points = append(points, debugPoint{Attributes: debugAttributes(dp.Attributes), Value: &value})
+	}
+	return points
+}
+
+func histogramPoints[N int64 | float64](dps []metricdata.HistogramDataPoint[N]) []debugPoint {
+	points := make([]debugPoint, 0, len(dps))
+	for _, dp := range dps {
+		sum := float64(dp.Sum)
+		points = append(points, debugPoint{
+			Attributes:   debugAttributes(dp.Attributes),
+			Count:        dp.Count,
+			Sum:          &sum,
+			Bounds:       dp.Bounds,
+			BucketCounts: dp.BucketCounts,
+		})
+	}
+	return points
+}
+
+func exponentialPoints[N int64 | float64](dps []metricdata.ExponentialHistogramDataPoint[N]) []debugPoint {
+	points := make([]debugPoint, 0, len(dps))
+	for _, dp := range dps {
+		sum := float64(dp.Sum)
+		points = append(points, debugPoint{Attributes: debugAttributes(dp.Attributes), Count: dp.Count, Sum: &sum})
+	}
+	return points
+}
+
+// snapshot monta o conteúdo da página. Os filtros são aplicados por substring do nome:
+// instrument filtra as métricas e span filtra os spans.
+func (d *debugState) snapshot(ctx context.Context, instrument, span string) (debugSnapshot, error) {
+	metrics, err := d.collectMetrics(ctx, instrument)
+	if err != nil {
+		return debugSnapshot{}, err
+	}
+
+	var spans []debugSpan
+	for _, s := range d.spans.snapshot() {
+		if strings.Contains(s.Name, span) {
+			spans = append(spans, s)
+		}
+	}
+
+	return debugSnapshot{
+		Time:    time.Now(),
+		Metrics: metrics,
+		Logs:    d.logs.snapshot(),
+		Spans:   spans,
+	}, nil
+}
+
+// ServeHTTP renderiza o snapshot em HTML ou, com ?format=json ou Accept: application/json, em JSON.
+// Aceita os filtros ?instrument=<nome> e ?span=<nome>.
+func (d *debugState) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	snapshot, err := d.snapshot(r.Context(), query.Get("instrument"), query.Get("span"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if query.Get("format") == "json" || (query.Get("format") == "" && strings.Contains(r.Header.Get("Accept"), "application/json")) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(snapshot)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = debugTemplate.Execute(w, struct {
+		debugSnapshot
+		Instrument, Span string
+	}{snapshot, query.Get("instrument"), query.Get("span")})
+}
+
+var debugTemplate = template.Must(template.New("debug").Funcs(template.FuncMap{
+	"deref": func(v *float64) any {
+		if v == nil {
+			return ""
+		}
+		return *v
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>graftel debug</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; margin: 16px; }
+table { border-collapse: collapse; margin-bottom: 24px; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+code { font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>graftel debug</h1>
+<p>{{.Time.Format "2006-01-02 15:04:05.000 MST"}} &middot; <a href="?format=json&amp;instrument={{.Instrument}}&amp;span={{.Span}}">JSON</a></p>
+<form method="get">
+Instrumento: <input name="instrument" value="{{.Instrument}}">
+Span: <input name="span" value="{{.Span}}">
+<button type="submit">Filtrar</button>
+</form>
+
+<h2>Métricas ({{len .Metrics}})</h2>
+<table>
+<tr><th>Nome</th><th>Escopo</th><th>Tipo</th><th>Atributos</th><th>Valor</th><th>Count</th><th>Sum</th><th>Buckets</th></tr>
+{{range $m := .Metrics}}{{range .Points}}
+<tr><td title="{{$m.Description}}">{{$m.Name}}{{if $m.Unit}} ({{$m.Unit}}){{end}}</td><td>{{$m.Scope}}</td><td>{{$m.Type}}</td>
+<td>{{range $k, $v := .Attributes}}<code>{{$k}}={{$v}}</code> {{end}}</td>
+<td>{{deref .Value}}</td><td>{{if .Count}}{{.Count}}{{end}}</td><td>{{deref .Sum}}</td>
+<td>{{if .Bounds}}<code>{{.Bounds}} {{.BucketCounts}}</code>{{end}}</td></tr>
+{{end}}{{end}}
+</table>
+
+<h2>Logs recentes ({{len .Logs}})</h2>
+<table>
+<tr><th>Horário</th><th>Severidade</th><th>Escopo</th><th>Mensagem</th><th>Atributos</th><th>Trace</th></tr>
+{{range .Logs}}
+<tr><td>{{.Time.Format "15:04:05.000"}}</td><td>{{.Severity}}</td><td>{{.Scope}}</td><td>{{.Body}}</td>
+<td>{{range $k, $v := .Attributes}}<code>{{$k}}={{$v}}</code> {{end}}</td><td><code>{{.TraceID}}</code></td></tr>
+{{end}}
+</table>
+
+<h2>Spans recentes ({{len .Spans}})</h2>
+<table>
+<tr><th>Início</th><th>Nome</th><th>Tipo</th><th>Duração</th><th>Status</th><th>Atributos</th><th>Trace / Span</th></tr>
+{{range .Spans}}
+<tr><td>{{.Start.Format "15:04:05.000"}}</td><td>{{.Name}}</td><td>{{.Kind}}</td><td>{{.Duration}}</td>
+<td>{{.Status}}{{if .StatusDesc}}: {{.StatusDesc}}{{end}}</td>
+<td>{{range $k, $v := .Attributes}}<code>{{$k}}={{$v}}</code> {{end}}</td>
+<td><code>{{.TraceID}} / {{.SpanID}}</code></td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
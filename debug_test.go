@@ -0,0 +1,165 @@
+package graftel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func createDebugClient(t *testing.T) Client {
+	t.Helper()
+	client, err := NewClient(NewConfig("test-service").WithInsecure(true).WithDebugHandler(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func getDebugSnapshot(t *testing.T, handler http.Handler, query string) debugSnapshot {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug?format=json"+query, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var snapshot debugSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	return snapshot
+}
+
+func TestClient_DebugHandler(t *testing.T) {
+	client := createDebugClient(t)
+	handler := client.(DebugHandlerProvider).DebugHandler()
+	if handler == nil {
+		t.Fatal("DebugHandler() não deveria ser nil com Debug habilitado")
+	}
+	ctx := context.Background()
+
+	helper := client.NewMetricsHelper("orders")
+	counter, _ := helper.NewCounter("orders_total", "Pedidos")
+	counter.Add(ctx, 3, attribute.String("status", "ok"))
+	histogram, _ := helper.NewHistogram("order_value", "Valor", WithBuckets(10, 100))
+	histogram.Record(ctx, 42)
+
+	tracing := client.NewTracingHelper("orders")
+	spanCtx, span := tracing.StartSpan(ctx, "checkout")
+	client.NewLogsHelper("orders").Info(spanCtx, "pedido criado", attribute.String("order_id", "123"))
+	span.End()
+	_, other := tracing.StartSpan(ctx, "refund")
+	other.End()
+
+	snapshot := getDebugSnapshot(t, handler, "")
+
+	var names []string
+	for _, m := range snapshot.Metrics {
+		names = append(names, m.Name)
+	}
+	if !slices.Contains(names, "orders_total") || !slices.Contains(names, "order_value") {
+		t.Errorf("métricas = %v", names)
+	}
+	for _, m := range snapshot.Metrics {
+		if m.Name == "orders_total" && *m.Points[0].Value != 3 {
+			t.Errorf("orders_total = %v, esperado 3", *m.Points[0].Value)
+		}
+		if m.Name == "order_value" && (m.Points[0].Count != 1 || m.Points[0].BucketCounts[1] != 1) {
+			t.Errorf("order_value = %+v", m.Points[0])
+		}
+	}
+
+	if len(snapshot.Logs) != 1 || !strings.HasPrefix(snapshot.Logs[0].Body, "pedido criado") ||
+		snapshot.Logs[0].Attributes["tags.order_id"] != "123" || snapshot.Logs[0].Time.IsZero() {
+		t.Fatalf("logs = %+v", snapshot.Logs)
+	}
+	if len(snapshot.Spans) != 2 || snapshot.Spans[0].Name != "refund" {
+		t.Fatalf("spans (mais recente primeiro) = %+v", snapshot.Spans)
+	}
+	if snapshot.Logs[0].TraceID != snapshot.Spans[1].TraceID {
+		t.Error("log deveria ter o trace_id do span checkout")
+	}
+
+	filtered := getDebugSnapshot(t, handler, "&instrument=orders_&span=check")
+	if len(filtered.Metrics) != 1 || filtered.Metrics[0].Name != "orders_total" {
+		t.Errorf("filtro de instrumento = %+v", filtered.Metrics)
+	}
+	if len(filtered.Spans) != 1 || filtered.Spans[0].Name != "checkout" {
+		t.Errorf("filtro de span = %+v", filtered.Spans)
+	}
+}
+
+func TestClient_DebugHandlerHTML(t *testing.T) {
+	client := createDebugClient(t)
+	counter, _ := client.NewMetricsHelper("orders").NewCounter("orders_total", "Pedidos")
+	counter.Increment(context.Background(), attribute.String("status", "<ok>"))
+
+	rec := httptest.NewRecorder()
+	client.(DebugHandlerProvider).DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug", nil))
+
+	body := rec.Body.String()
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(body, "orders_total") || !strings.Contains(body, "status=&lt;ok&gt;") {
+		t.Errorf("HTML não contém a métrica escapada:\n%s", body)
+	}
+}
+
+func TestClient_DebugHandlerDisabled(t *testing.T) {
+	client := createTestClientForMiddleware()
+	defer client.Shutdown(context.Background())
+
+	rec := httptest.NewRecorder()
+	client.(DebugHandlerProvider).DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, esperado 404 sem WithDebugHandler", rec.Code)
+	}
+}
+
+func TestDebugRing(t *testing.T) {
+	ring := newDebugRing[int](3)
+	if got := ring.snapshot(); len(got) != 0 {
+		t.Errorf("snapshot vazio = %v", got)
+	}
+	for i := 1; i <= 5; i++ {
+		ring.add(i)
+	}
+	if got := ring.snapshot(); !slices.Equal(got, []int{5, 4, 3}) {
+		t.Errorf("snapshot = %v, esperado [5 4 3]", got)
+	}
+}
+
+func TestConfig_Debug(t *testing.T) {
+	t.Setenv("GRAFTEL_DEBUG", "true")
+	t.Setenv("GRAFTEL_DEBUG_BUFFER_SIZE", "20")
+
+	config := NewConfig("test")
+	if !config.Debug || config.DebugBufferSize != 20 {
+		t.Errorf("Debug via ENV = %v, %d", config.Debug, config.DebugBufferSize)
+	}
+
+	defaults := Config{ServiceName: "test"}.WithDebugHandler(0)
+	if err := defaults.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if defaults.DebugBufferSize != DefaultDebugBufferSize {
+		t.Errorf("DebugBufferSize = %d, esperado %d", defaults.DebugBufferSize, DefaultDebugBufferSize)
+	}
+
+	negative := Config{ServiceName: "test"}.WithDebugHandler(-1)
+	var invalid *ErrInvalidConfig
+	if err := negative.Validate(); !errors.As(err, &invalid) || invalid.Field != "DebugBufferSize" {
+		t.Errorf("esperado erro de DebugBufferSize negativo, obtido %v", err)
+	}
+}
@@ -42,3 +42,39 @@ func (e *ErrShutdownFailed) Error() string {
 func (e *ErrShutdownFailed) Unwrap() error {
 	return e.Err
 }
+
+// ErrCardinalityLimitExceeded é reportado ao error handler quando um instrumento
+// atinge seu limite de cardinalidade e passa a agregar medições na série de overflow.
+type ErrCardinalityLimitExceeded struct {
+	Instrument string
+	Limit      int
+	TopKeys    []AttributeKeyCardinality
+}
+
+func (e *ErrCardinalityLimitExceeded) Error() string {
+	msg := fmt.Sprintf("limite de cardinalidade excedido em '%s' (limite %d): novas séries agregadas em otel.metric.overflow", e.Instrument, e.Limit)
+	if len(e.TopKeys) > 0 {
+		top := e.TopKeys
+		if len(top) > 3 {
+			top = top[:3]
+		}
+		msg += "; chaves com mais valores:"
+		for _, k := range top {
+			msg += fmt.Sprintf(" %s=%d", k.Key, k.DistinctValues)
+		}
+	}
+	return msg
+}
+
+// ErrInstrumentConflict é retornado pelo MetricsHelper quando um instrumento já registrado
+// com o mesmo nome é solicitado com tipo, descrição, unidade ou buckets diferentes.
+type ErrInstrumentConflict struct {
+	Instrument string
+	Field      string
+	Existing   string
+	Requested  string
+}
+
+func (e *ErrInstrumentConflict) Error() string {
+	return fmt.Sprintf("conflito no instrumento '%s': %s registrado '%s', solicitado '%s'", e.Instrument, e.Field, e.Existing, e.Requested)
+}
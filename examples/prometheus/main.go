@@ -52,15 +52,13 @@ func main() {
 		log.Fatalf("Falha ao criar contador: %v", err)
 	}
 
-	// Expor endpoint Prometheus
-	exporter := client.GetPrometheusExporter()
-	if exporter != nil {
-		http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
-			// O exporter do Prometheus precisa ser usado com promhttp
-			// Por enquanto, vamos apenas informar que está configurado
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("# Métricas Prometheus configuradas\n# Use o exporter diretamente com promhttp\n"))
-		})
+	// Expor endpoint Prometheus (OpenMetrics com exemplars quando solicitado pelo scraper)
+	var handler http.Handler
+	if p, ok := client.(graftel.PrometheusHandlerProvider); ok {
+		handler = p.PrometheusHandler()
+	}
+	if handler != nil {
+		http.Handle("/metrics", handler)
 		log.Println("Métricas Prometheus disponíveis em http://localhost:8080/metrics")
 	} else {
 		log.Println("Exporter Prometheus não configurado")
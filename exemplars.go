@@ -0,0 +1,94 @@
+package graftel
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+)
+
+// ExemplarFilter define quais medições podem virar exemplars, ligando amostras
+// de métricas (ex: latência) ao trace que as produziu.
+type ExemplarFilter int
+
+const (
+	// ExemplarFilterTraceBased coleta exemplars apenas de medições feitas com um span
+	// amostrado no contexto. É o padrão.
+	ExemplarFilterTraceBased ExemplarFilter = iota
+	// ExemplarFilterAlwaysOn considera todas as medições como candidatas a exemplar.
+	ExemplarFilterAlwaysOn
+	// ExemplarFilterAlwaysOff desabilita a coleta de exemplars.
+	ExemplarFilterAlwaysOff
+)
+
+// String retorna a representação em string do filtro, no formato de OTEL_METRICS_EXEMPLAR_FILTER.
+func (f ExemplarFilter) String() string {
+	switch f {
+	case ExemplarFilterTraceBased:
+		return "trace_based"
+	case ExemplarFilterAlwaysOn:
+		return "always_on"
+	case ExemplarFilterAlwaysOff:
+		return "always_off"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseExemplarFilter converte "trace_based", "always_on" ou "always_off" em ExemplarFilter.
+func ParseExemplarFilter(s string) (ExemplarFilter, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace_based":
+		return ExemplarFilterTraceBased, nil
+	case "always_on":
+		return ExemplarFilterAlwaysOn, nil
+	case "always_off":
+		return ExemplarFilterAlwaysOff, nil
+	default:
+		return ExemplarFilterTraceBased, fmt.Errorf("filtro de exemplar inválido: %q", s)
+	}
+}
+
+// sdkFilter converte o filtro para o formato do SDK.
+func (f ExemplarFilter) sdkFilter() exemplar.Filter {
+	switch f {
+	case ExemplarFilterAlwaysOn:
+		return exemplar.AlwaysOnFilter
+	case ExemplarFilterAlwaysOff:
+		return exemplar.AlwaysOffFilter
+	default:
+		return exemplar.TraceBasedFilter
+	}
+}
+
+// WithExemplarFilter define quais medições viram exemplars.
+func (c Config) WithExemplarFilter(filter ExemplarFilter) Config {
+	c.ExemplarFilter = filter
+	return c
+}
+
+// PrometheusHandlerProvider é implementado pelo Client retornado por NewClient. Use uma
+// type assertion para obter o handler sem depender da interface Client:
+//
+//	if p, ok := client.(graftel.PrometheusHandlerProvider); ok {
+//		if handler := p.PrometheusHandler(); handler != nil {
+//			http.Handle("/metrics", handler)
+//		}
+//	}
+type PrometheusHandlerProvider interface {
+	// PrometheusHandler retorna um http.Handler que expõe as métricas no formato
+	// OpenMetrics (com exemplars) quando solicitado pelo scraper.
+	// Retorna nil se Prometheus não estiver habilitado.
+	PrometheusHandler() http.Handler
+}
+
+// prometheusHandler expõe as métricas do gatherer, negociando o formato OpenMetrics
+// para que os exemplars sejam incluídos.
+func prometheusHandler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	})
+}
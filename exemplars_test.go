@@ -0,0 +1,181 @@
+package graftel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContext inicia um span amostrado e retorna o contexto que o contém.
+func spanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	t.Cleanup(func() { span.End() })
+	return ctx, span.SpanContext()
+}
+
+func TestParseExemplarFilter(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ExemplarFilter
+		wantErr bool
+	}{
+		{"trace_based", ExemplarFilterTraceBased, false},
+		{"ALWAYS_ON", ExemplarFilterAlwaysOn, false},
+		{" always_off ", ExemplarFilterAlwaysOff, false},
+		{"sometimes", ExemplarFilterTraceBased, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseExemplarFilter(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExemplarFilter() erro = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseExemplarFilter() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != strings.ToLower(strings.TrimSpace(tt.input)) {
+				t.Errorf("String() = %q", got.String())
+			}
+		})
+	}
+}
+
+func TestExemplars_TraceBased(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithExemplarFilter(ExemplarFilterTraceBased.sdkFilter()))
+	ctx, sc := spanContext(t)
+
+	histogram, err := helper.NewHistogram("http_request_duration_seconds", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := helper.NewCounter("http_requests_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	histogram.Record(ctx, 0.25)
+	counter.Add(ctx, 1)
+	// Medições sem span não geram exemplars
+	histogram.Record(context.Background(), 0.5)
+
+	metrics := collectMetrics(t, reader)
+
+	hist := metrics["http_request_duration_seconds"].Data.(metricdata.Histogram[float64])
+	exemplars := hist.DataPoints[0].Exemplars
+	if len(exemplars) != 1 {
+		t.Fatalf("esperado 1 exemplar no histograma, obtido %d", len(exemplars))
+	}
+	if trace.TraceID(exemplars[0].TraceID) != sc.TraceID() {
+		t.Errorf("TraceID do exemplar = %x, want %s", exemplars[0].TraceID, sc.TraceID())
+	}
+	if trace.SpanID(exemplars[0].SpanID) != sc.SpanID() {
+		t.Errorf("SpanID do exemplar = %x, want %s", exemplars[0].SpanID, sc.SpanID())
+	}
+	if exemplars[0].Value != 0.25 {
+		t.Errorf("valor do exemplar = %v, want 0.25", exemplars[0].Value)
+	}
+
+	sum := metrics["http_requests_total"].Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints[0].Exemplars) != 1 {
+		t.Errorf("esperado 1 exemplar no contador, obtido %d", len(sum.DataPoints[0].Exemplars))
+	}
+}
+
+func TestExemplars_AlwaysOff(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithExemplarFilter(ExemplarFilterAlwaysOff.sdkFilter()))
+	ctx, _ := spanContext(t)
+
+	histogram, _ := helper.NewHistogram("latency", "test")
+	histogram.Record(ctx, 0.25)
+
+	hist := collectMetrics(t, reader)["latency"].Data.(metricdata.Histogram[float64])
+	if n := len(hist.DataPoints[0].Exemplars); n != 0 {
+		t.Errorf("esperado nenhum exemplar, obtido %d", n)
+	}
+}
+
+func TestPrometheusHandler_OpenMetricsExemplars(t *testing.T) {
+	registry := promclient.NewRegistry()
+	exporter, err := prometheus.New(prometheus.WithRegisterer(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithExemplarFilter(ExemplarFilterTraceBased.sdkFilter()),
+	)
+	helper := NewMetricsHelper(provider.Meter("test"))
+	ctx, sc := spanContext(t)
+
+	histogram, _ := helper.NewHistogram("request_duration_seconds", "test")
+	histogram.Record(ctx, 0.25)
+
+	server := httptest.NewServer(prometheusHandler(registry))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/openmetrics-text") {
+		t.Errorf("Content-Type = %q, esperado OpenMetrics", resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), `trace_id="`+sc.TraceID().String()+`"`) {
+		t.Errorf("exemplar com trace_id não encontrado na saída:\n%s", body)
+	}
+}
+
+func TestConfig_ExemplarFilter(t *testing.T) {
+	os.Setenv("OTEL_METRICS_EXEMPLAR_FILTER", "always_off")
+	defer os.Unsetenv("OTEL_METRICS_EXEMPLAR_FILTER")
+
+	config := NewConfig("test-service")
+	if config.ExemplarFilter != ExemplarFilterAlwaysOff {
+		t.Errorf("ExemplarFilter = %v, esperado always_off", config.ExemplarFilter)
+	}
+
+	config = config.WithExemplarFilter(ExemplarFilterAlwaysOn)
+	if config.ExemplarFilter != ExemplarFilterAlwaysOn {
+		t.Errorf("ExemplarFilter = %v, esperado always_on", config.ExemplarFilter)
+	}
+
+	invalid := config.WithExemplarFilter(ExemplarFilter(42))
+	if err := invalid.Validate(); err == nil {
+		t.Error("esperado erro para filtro desconhecido")
+	}
+}
+
+func TestClient_PrometheusHandlerProvider(t *testing.T) {
+	cl, err := NewClient(NewConfig("test-service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider, ok := cl.(PrometheusHandlerProvider)
+	if !ok {
+		t.Fatal("Client deveria implementar PrometheusHandlerProvider")
+	}
+	if provider.PrometheusHandler() != nil {
+		t.Error("esperado handler nil sem Prometheus habilitado")
+	}
+}
@@ -0,0 +1,100 @@
+// Package graftellogrus encaminha os logs do logrus para o Client do graftel.
+//
+// Exemplo:
+//
+//	logrus.AddHook(graftellogrus.NewHook(client, "meu-servico"))
+//	logrus.WithContext(ctx).WithField("order_id", 42).Info("pedido criado")
+package graftellogrus
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// flushTimeout limita o flush feito após as entradas Panic e Fatal.
+const flushTimeout = 5 * time.Second
+
+// Hook implementa logrus.Hook emitindo as entradas pelo LogsHelper do Client.
+type Hook struct {
+	logs   graftel.LogsHelper
+	flush  func(context.Context) error
+	levels []logrus.Level
+}
+
+// NewHook cria um logrus.Hook que envia as entradas pelo Logger name do client, com o
+// mesmo mapeamento de severidade e conversão de atributos do LogsHelper. Sem levels,
+// todos os níveis são enviados. O ctx de Entry.WithContext é usado para correlacionar
+// o log com o trace.
+//
+// As entradas Panic e Fatal fazem o flush do client (graftel.Flusher) antes de o logrus
+// entrar em pânico ou encerrar o processo, para que o último registro não se perca.
+func NewHook(client graftel.Client, name string, levels ...logrus.Level) *Hook {
+	if len(levels) == 0 {
+		levels = logrus.AllLevels
+	}
+	h := &Hook{logs: client.NewLogsHelper(name), levels: levels}
+	if f, ok := client.(graftel.Flusher); ok {
+		h.flush = f.ForceFlush
+	}
+	return h
+}
+
+// Levels implementa logrus.Hook.
+func (h *Hook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire implementa logrus.Hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := logLevel(entry.Level)
+	if !graftel.LogEnabled(ctx, h.logs, level) {
+		return nil
+	}
+
+	// Campos em ordem alfabética, para um body estável
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tags := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, graftel.FieldAttribute(key, entry.Data[key]))
+	}
+
+	h.logs.Log(ctx, level, entry.Message, tags...)
+	if entry.Level <= logrus.FatalLevel && h.flush != nil {
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+		defer cancel()
+		return h.flush(flushCtx)
+	}
+	return nil
+}
+
+// logLevel converte um nível do logrus. Panic e Fatal viram LogLevelFatal.
+func logLevel(level logrus.Level) graftel.LogLevel {
+	switch level {
+	case logrus.TraceLevel:
+		return graftel.LogLevelTrace
+	case logrus.DebugLevel:
+		return graftel.LogLevelDebug
+	case logrus.InfoLevel:
+		return graftel.LogLevelInfo
+	case logrus.WarnLevel:
+		return graftel.LogLevelWarn
+	case logrus.ErrorLevel:
+		return graftel.LogLevelError
+	default:
+		return graftel.LogLevelFatal
+	}
+}
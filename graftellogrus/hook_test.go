@@ -0,0 +1,92 @@
+package graftellogrus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"github.com/CristianSsousa/graftel/v2/internal/logtest"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestHook(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(NewHook(client, "logrus"))
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "op")
+	logger.WithContext(ctx).
+		WithFields(logrus.Fields{"order_id": 42, "status": "recusado"}).
+		WithError(errors.New("saldo insuficiente")).
+		Warn("pagamento recusado")
+	span.End()
+	logtest.Flush(t, client)
+
+	got := exporter.Records()
+	if len(got) != 1 {
+		t.Fatalf("esperado 1 log, obtidos %d", len(got))
+	}
+	record := got[0]
+	if record.Severity() != otellog.SeverityWarn || record.Body().AsString() != "pagamento recusado [error:saldo insuficiente][order_id:42][status:recusado]" {
+		t.Errorf("log = %s %q", record.Severity(), record.Body().AsString())
+	}
+	if attrs := logtest.Attributes(record); attrs["tags.order_id"].AsInt64() != 42 {
+		t.Errorf("atributos = %v", attrs)
+	}
+	if record.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("trace_id = %s, esperado %s", record.TraceID(), span.SpanContext().TraceID())
+	}
+}
+
+func TestHook_Levels(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(NewHook(client, "logrus", logrus.ErrorLevel))
+
+	logger.Info("ignorado pelo hook")
+	logger.Error("enviado")
+	logtest.Flush(t, client)
+
+	if got := exporter.Records(); len(got) != 1 || got[0].Body().AsString() != "enviado" {
+		t.Errorf("esperado apenas o log de erro, obtidos %d", len(got))
+	}
+}
+
+func TestHook_FatalFlushes(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.ExitFunc = func(int) {}
+	logger.AddHook(NewHook(client, "logrus"))
+
+	logger.Fatal("falha ao iniciar")
+
+	// Sem flush explícito: o hook envia o registro antes de o logrus encerrar o processo
+	got := exporter.Records()
+	if len(got) != 1 || got[0].Severity() != otellog.SeverityFatal || got[0].Body().AsString() != "falha ao iniciar" {
+		t.Errorf("esperado o registro do Fatal no exporter, obtidos %d", len(got))
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := map[logrus.Level]graftel.LogLevel{
+		logrus.TraceLevel: graftel.LogLevelTrace,
+		logrus.DebugLevel: graftel.LogLevelDebug,
+		logrus.InfoLevel:  graftel.LogLevelInfo,
+		logrus.WarnLevel:  graftel.LogLevelWarn,
+		logrus.ErrorLevel: graftel.LogLevelError,
+		logrus.FatalLevel: graftel.LogLevelFatal,
+		logrus.PanicLevel: graftel.LogLevelFatal,
+	}
+	for level, want := range tests {
+		if got := logLevel(level); got != want {
+			t.Errorf("logLevel(%v) = %v, esperado %v", level, got, want)
+		}
+	}
+}
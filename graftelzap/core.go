@@ -0,0 +1,134 @@
+// Package graftelzap encaminha os logs do zap para o Client do graftel.
+//
+// Exemplo:
+//
+//	core := graftelzap.NewCore(client, "meu-servico", zapcore.InfoLevel)
+//	logger := zap.New(zapcore.NewTee(consoleCore, core))
+//	logger.Info("pedido criado", zap.Int("order_id", 42), zap.Any("ctx", ctx))
+package graftelzap
+
+import (
+	"context"
+	"time"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+)
+
+// flushTimeout limita o flush feito por Sync e pelas entradas DPanic ou acima.
+const flushTimeout = 5 * time.Second
+
+// core implementa zapcore.Core emitindo as entradas pelo LogsHelper do Client.
+type core struct {
+	zapcore.LevelEnabler
+	logs   graftel.LogsHelper
+	flush  func(context.Context) error
+	fields []zapcore.Field
+}
+
+// NewCore cria um zapcore.Core que envia as entradas pelo Logger name do client, com o
+// mesmo mapeamento de severidade e conversão de atributos do LogsHelper. level define o
+// nível mínimo; nil habilita todos os níveis. O nível mínimo do logger no Client
+// (Config.LogLevel) também é respeitado.
+//
+// O zap não propaga context.Context: para correlacionar o log com o trace, passe o ctx
+// como campo (ex: zap.Any("ctx", ctx)). Esse campo não vira atributo.
+//
+// Sync e as entradas DPanic, Panic e Fatal fazem o flush do client (graftel.Flusher),
+// para que o último registro não se perca quando o zap encerra o processo.
+func NewCore(client graftel.Client, name string, level zapcore.LevelEnabler) zapcore.Core {
+	if level == nil {
+		level = zapcore.DebugLevel - 1
+	}
+	c := &core{LevelEnabler: level, logs: client.NewLogsHelper(name)}
+	if f, ok := client.(graftel.Flusher); ok {
+		c.flush = f.ForceFlush
+	}
+	return c
+}
+
+// With implementa zapcore.Core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	return &clone
+}
+
+// Enabled implementa zapcore.LevelEnabler, combinando level com o nível do LogsHelper.
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.LevelEnabler.Enabled(level) && graftel.LogEnabled(context.Background(), c.logs, logLevel(level))
+}
+
+// Check implementa zapcore.Core.
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implementa zapcore.Core.
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ctx := context.Background()
+	tags := make([]attribute.KeyValue, 0, len(c.fields)+len(fields))
+	for _, group := range [][]zapcore.Field{c.fields, fields} {
+		for _, f := range group {
+			if fieldCtx, ok := f.Interface.(context.Context); ok {
+				ctx = fieldCtx
+				continue
+			}
+			if tag, ok := fieldAttribute(f); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	c.logs.Log(ctx, logLevel(entry.Level), entry.Message, tags...)
+	if entry.Level >= zapcore.DPanicLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+// Sync implementa zapcore.Core, enviando os registros pendentes do Client.
+func (c *core) Sync() error {
+	if c.flush == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	return c.flush(ctx)
+}
+
+// fieldAttribute converte um campo do zap usando o encoder de mapa do próprio zap.
+func fieldAttribute(f zapcore.Field) (attribute.KeyValue, bool) {
+	if f.Type == zapcore.SkipType {
+		return attribute.KeyValue{}, false
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	value, ok := enc.Fields[f.Key]
+	if !ok {
+		return attribute.KeyValue{}, false
+	}
+	return graftel.FieldAttribute(f.Key, value), true
+}
+
+// logLevel converte um nível do zap. DPanic, Panic e Fatal viram LogLevelFatal.
+func logLevel(level zapcore.Level) graftel.LogLevel {
+	switch {
+	case level < zapcore.DebugLevel:
+		return graftel.LogLevelTrace
+	case level == zapcore.DebugLevel:
+		return graftel.LogLevelDebug
+	case level == zapcore.InfoLevel:
+		return graftel.LogLevelInfo
+	case level == zapcore.WarnLevel:
+		return graftel.LogLevelWarn
+	case level == zapcore.ErrorLevel:
+		return graftel.LogLevelError
+	default:
+		return graftel.LogLevelFatal
+	}
+}
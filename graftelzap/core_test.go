@@ -0,0 +1,80 @@
+package graftelzap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"github.com/CristianSsousa/graftel/v2/internal/logtest"
+	"go.opentelemetry.io/otel"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// noExit substitui o os.Exit do Fatal nos testes.
+type noExit struct{}
+
+func (noExit) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+func TestCore(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := zap.New(NewCore(client, "zap", zapcore.InfoLevel)).With(zap.String("service", "api"))
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "op")
+	logger.Warn("pedido atrasado", zap.Int("order_id", 42), zap.Duration("delay", 0), zap.Any("ctx", ctx))
+	span.End()
+	logger.Debug("ignorado pelo nível")
+	if err := logger.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := exporter.Records()
+	if len(got) != 1 {
+		t.Fatalf("esperado 1 log, obtidos %d", len(got))
+	}
+	record := got[0]
+	if record.Severity() != otellog.SeverityWarn || record.Body().AsString() != "pedido atrasado [service:api][order_id:42][delay:0s]" {
+		t.Errorf("log = %s %q", record.Severity(), record.Body().AsString())
+	}
+	attrs := logtest.Attributes(record)
+	if attrs["tags.order_id"].AsInt64() != 42 || attrs["tags.service"].AsString() != "api" {
+		t.Errorf("atributos = %v", attrs)
+	}
+	if _, ok := attrs["tags.ctx"]; ok {
+		t.Error("o campo ctx não deveria virar atributo")
+	}
+	if record.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("trace_id = %s, esperado %s", record.TraceID(), span.SpanContext().TraceID())
+	}
+}
+
+func TestCore_FatalFlushes(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := zap.New(NewCore(client, "zap", nil), zap.WithFatalHook(noExit{}))
+
+	logger.Fatal("falha ao iniciar")
+
+	// Sem Sync explícito: o próprio Fatal envia o registro ao exporter
+	got := exporter.Records()
+	if len(got) != 1 || got[0].Severity() != otellog.SeverityFatal || got[0].Body().AsString() != "falha ao iniciar" {
+		t.Errorf("esperado o registro do Fatal no exporter, obtidos %d", len(got))
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := map[zapcore.Level]graftel.LogLevel{
+		zapcore.DebugLevel - 1: graftel.LogLevelTrace,
+		zapcore.DebugLevel:     graftel.LogLevelDebug,
+		zapcore.InfoLevel:      graftel.LogLevelInfo,
+		zapcore.WarnLevel:      graftel.LogLevelWarn,
+		zapcore.ErrorLevel:     graftel.LogLevelError,
+		zapcore.DPanicLevel:    graftel.LogLevelFatal,
+		zapcore.FatalLevel:     graftel.LogLevelFatal,
+	}
+	for level, want := range tests {
+		if got := logLevel(level); got != want {
+			t.Errorf("logLevel(%v) = %v, esperado %v", level, got, want)
+		}
+	}
+}
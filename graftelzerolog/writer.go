@@ -0,0 +1,169 @@
+// Package graftelzerolog encaminha os logs do zerolog para o Client do graftel.
+//
+// Exemplo:
+//
+//	writer := graftelzerolog.NewWriter(client, "meu-servico")
+//	logger := zerolog.New(zerolog.MultiLevelWriter(os.Stdout, writer)).
+//		Hook(graftelzerolog.TraceHook{})
+//	logger.Info().Ctx(ctx).Int("order_id", 42).Msg("pedido criado")
+package graftelzerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIDFieldName é o campo onde TraceHook grava o trace_id do contexto do evento.
+	TraceIDFieldName = "trace_id"
+	// SpanIDFieldName é o campo onde TraceHook grava o span_id do contexto do evento.
+	SpanIDFieldName = "span_id"
+)
+
+// TraceHook grava trace_id e span_id do contexto do evento (Event.Ctx) como campos.
+// O Writer usa esses campos para correlacionar o log com o trace.
+type TraceHook struct{}
+
+// Run implementa zerolog.Hook.
+func (TraceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	sc := trace.SpanContextFromContext(e.GetCtx())
+	if sc.IsValid() {
+		e.Str(TraceIDFieldName, sc.TraceID().String()).Str(SpanIDFieldName, sc.SpanID().String())
+	}
+}
+
+// flushTimeout limita o flush feito após os eventos Panic e Fatal.
+const flushTimeout = 5 * time.Second
+
+// writer implementa zerolog.LevelWriter decodificando cada evento JSON.
+type writer struct {
+	logs  graftel.LogsHelper
+	flush func(context.Context) error
+}
+
+// NewWriter cria um zerolog.LevelWriter que envia os eventos pelo Logger name do client,
+// com o mesmo mapeamento de severidade e conversão de atributos do LogsHelper. Use com
+// zerolog.MultiLevelWriter para manter a saída original e com TraceHook para
+// correlacionar os logs com o trace.
+//
+// Os eventos Panic e Fatal fazem o flush do client (graftel.Flusher) antes de o zerolog
+// entrar em pânico ou encerrar o processo, para que o último registro não se perca.
+func NewWriter(client graftel.Client, name string) zerolog.LevelWriter {
+	w := &writer{logs: client.NewLogsHelper(name)}
+	if f, ok := client.(graftel.Flusher); ok {
+		w.flush = f.ForceFlush
+	}
+	return w
+}
+
+// Write implementa io.Writer, lendo o nível do campo zerolog.LevelFieldName.
+func (w *writer) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implementa zerolog.LevelWriter.
+func (w *writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// Eventos abaixo do nível mínimo do logger são descartados sem decodificar o JSON
+	if level != zerolog.NoLevel && !graftel.LogEnabled(context.Background(), w.logs, logLevel(level)) {
+		return len(p), nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, fmt.Errorf("evento zerolog inválido: %q", p)
+	}
+
+	var (
+		msg            string
+		traceID        trace.TraceID
+		spanID         trace.SpanID
+		tags           []attribute.KeyValue
+		levelFromField = level == zerolog.NoLevel
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, err
+		}
+		key, _ := tok.(string)
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return 0, err
+		}
+
+		switch key {
+		case zerolog.MessageFieldName:
+			msg = fmt.Sprint(value)
+		case zerolog.LevelFieldName:
+			if levelFromField {
+				if parsed, err := zerolog.ParseLevel(fmt.Sprint(value)); err == nil {
+					level = parsed
+				}
+			}
+		case zerolog.TimestampFieldName:
+		case TraceIDFieldName:
+			traceID, _ = trace.TraceIDFromHex(fmt.Sprint(value))
+		case SpanIDFieldName:
+			spanID, _ = trace.SpanIDFromHex(fmt.Sprint(value))
+		default:
+			if n, ok := value.(json.Number); ok {
+				value = number(n)
+			}
+			tags = append(tags, graftel.FieldAttribute(key, value))
+		}
+	}
+
+	ctx := context.Background()
+	if sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, Remote: true}); sc.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, sc)
+	}
+
+	w.logs.Log(ctx, logLevel(level), msg, tags...)
+	if (level == zerolog.FatalLevel || level == zerolog.PanicLevel) && w.flush != nil {
+		flushCtx, cancel := context.WithTimeout(ctx, flushTimeout)
+		defer cancel()
+		if err := w.flush(flushCtx); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// number converte um número JSON em int64 quando possível ou em float64.
+func number(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// logLevel converte um nível do zerolog. Panic e Fatal viram LogLevelFatal; eventos
+// sem nível viram LogLevelInfo.
+func logLevel(level zerolog.Level) graftel.LogLevel {
+	switch level {
+	case zerolog.TraceLevel:
+		return graftel.LogLevelTrace
+	case zerolog.DebugLevel:
+		return graftel.LogLevelDebug
+	case zerolog.WarnLevel:
+		return graftel.LogLevelWarn
+	case zerolog.ErrorLevel:
+		return graftel.LogLevelError
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		return graftel.LogLevelFatal
+	default:
+		return graftel.LogLevelInfo
+	}
+}
@@ -0,0 +1,92 @@
+package graftelzerolog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/CristianSsousa/graftel/v2"
+	"github.com/CristianSsousa/graftel/v2/internal/logtest"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestWriter(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	var stdout bytes.Buffer
+	logger := zerolog.New(zerolog.MultiLevelWriter(&stdout, NewWriter(client, "zerolog"))).
+		Hook(TraceHook{}).
+		With().Timestamp().Str("service", "api").Logger()
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "op")
+	logger.Error().Ctx(ctx).Int("order_id", 42).Float64("total", 9.5).Bool("retry", false).Msg("pagamento recusado")
+	span.End()
+	logtest.Flush(t, client)
+
+	got := exporter.Records()
+	if len(got) != 1 {
+		t.Fatalf("esperado 1 log, obtidos %d", len(got))
+	}
+	record := got[0]
+	if record.Severity() != otellog.SeverityError || record.Body().AsString() != "pagamento recusado [service:api][order_id:42][total:9.5][retry:false]" {
+		t.Errorf("log = %s %q", record.Severity(), record.Body().AsString())
+	}
+	if record.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("trace_id = %s, esperado %s", record.TraceID(), span.SpanContext().TraceID())
+	}
+	if _, ok := logtest.Attributes(record)["tags.trace_id"]; ok {
+		t.Error("trace_id não deveria virar atributo")
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte(`"trace_id":"`+span.SpanContext().TraceID().String())) {
+		t.Errorf("TraceHook deveria gravar o trace_id na saída original: %s", stdout.String())
+	}
+}
+
+func TestWriter_LevelFromField(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	writer := NewWriter(client, "zerolog")
+
+	if _, err := writer.Write([]byte(`{"level":"warn","message":"via Write"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write([]byte("não é JSON")); err == nil {
+		t.Error("esperado erro para evento inválido")
+	}
+	logtest.Flush(t, client)
+
+	if got := exporter.Records(); len(got) != 1 || got[0].Severity() != otellog.SeverityWarn || got[0].Body().AsString() != "via Write" {
+		t.Errorf("esperado 1 log WARN, obtidos %d", len(got))
+	}
+}
+
+func TestWriter_FatalFlushes(t *testing.T) {
+	client, exporter := logtest.NewClient(t)
+	logger := zerolog.New(NewWriter(client, "zerolog"))
+
+	// WithLevel registra no nível Fatal sem encerrar o processo
+	logger.WithLevel(zerolog.FatalLevel).Msg("falha ao iniciar")
+
+	got := exporter.Records()
+	if len(got) != 1 || got[0].Severity() != otellog.SeverityFatal || got[0].Body().AsString() != "falha ao iniciar" {
+		t.Errorf("esperado o registro do Fatal no exporter, obtidos %d", len(got))
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := map[zerolog.Level]graftel.LogLevel{
+		zerolog.TraceLevel: graftel.LogLevelTrace,
+		zerolog.DebugLevel: graftel.LogLevelDebug,
+		zerolog.InfoLevel:  graftel.LogLevelInfo,
+		zerolog.WarnLevel:  graftel.LogLevelWarn,
+		zerolog.ErrorLevel: graftel.LogLevelError,
+		zerolog.FatalLevel: graftel.LogLevelFatal,
+		zerolog.PanicLevel: graftel.LogLevelFatal,
+		zerolog.NoLevel:    graftel.LogLevelInfo,
+	}
+	for level, want := range tests {
+		if got := logLevel(level); got != want {
+			t.Errorf("logLevel(%v) = %v, esperado %v", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,520 @@
+package graftel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// errHostMetricsUnavailable indica que procDir não contém as estatísticas do Linux.
+var errHostMetricsUnavailable = errors.New("métricas de host exigem /proc do Linux")
+
+// diskSectorSize é o tamanho do setor usado por /proc/diskstats, fixo em 512 bytes.
+const diskSectorSize = 512
+
+// cpuModes são os modos de CPU na ordem das colunas da linha "cpu" de /proc/stat.
+var cpuModes = []string{"user", "nice", "system", "idle", "iowait", "interrupt", "softirq", "steal"}
+
+// cpuTimes são os tempos acumulados de CPU, em segundos, na ordem de cpuModes.
+type cpuTimes []float64
+
+func (t cpuTimes) total() float64 {
+	var sum float64
+	for _, v := range t {
+		sum += v
+	}
+	return sum
+}
+
+// memoryStats são os valores de /proc/meminfo, em bytes.
+type memoryStats struct {
+	Total     int64
+	Free      int64
+	Available int64
+	Buffers   int64
+	Cached    int64
+}
+
+// diskStats são os contadores de um dispositivo em /proc/diskstats.
+type diskStats struct {
+	Device     string
+	Reads      int64
+	Writes     int64
+	ReadBytes  int64
+	WriteBytes int64
+	IOTime     float64
+}
+
+// networkStats são os contadores de uma interface em /proc/net/dev.
+type networkStats struct {
+	Interface       string
+	ReceiveBytes    int64
+	ReceivePackets  int64
+	ReceiveErrors   int64
+	ReceiveDropped  int64
+	TransmitBytes   int64
+	TransmitPackets int64
+	TransmitErrors  int64
+	TransmitDropped int64
+}
+
+// hostSnapshot é uma leitura completa das estatísticas do host.
+type hostSnapshot struct {
+	CPU         cpuTimes
+	CPUCount    int64
+	Utilization cpuTimes
+	Memory      memoryStats
+	Disks       []diskStats
+	Networks    []networkStats
+}
+
+// hostCollector lê as estatísticas do host em /proc, no máximo uma vez por ttl.
+// A leitura é feita sob demanda pelos callbacks, a cada coleta do reader; ttl
+// apenas limita a frequência dessas leituras, reaproveitando o último snapshot.
+type hostCollector struct {
+	procDir    string
+	ttl        time.Duration
+	clockTicks float64
+
+	mu       sync.Mutex
+	readAt   time.Time
+	snapshot hostSnapshot
+}
+
+func newHostCollector(procDir string, ttl time.Duration) *hostCollector {
+	return &hostCollector{
+		procDir:    procDir,
+		ttl:        ttl,
+		clockTicks: float64(readClockTicks(filepath.Join(procDir, "self", "auxv"))),
+	}
+}
+
+// collect retorna a última leitura se ela for mais recente que ttl;
+// caso contrário lê /proc novamente e calcula a utilização de CPU desde a leitura anterior.
+func (hc *hostCollector) collect() (hostSnapshot, error) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if !hc.readAt.IsZero() && time.Since(hc.readAt) < hc.ttl {
+		return hc.snapshot, nil
+	}
+
+	var snap hostSnapshot
+	var err error
+	if snap.CPU, snap.CPUCount, err = readCPUStats(filepath.Join(hc.procDir, "stat"), hc.clockTicks); err != nil {
+		return hc.snapshot, err
+	}
+	if snap.Memory, err = readMemoryStats(filepath.Join(hc.procDir, "meminfo")); err != nil {
+		return hc.snapshot, err
+	}
+	if snap.Disks, err = readDiskStats(filepath.Join(hc.procDir, "diskstats")); err != nil {
+		return hc.snapshot, err
+	}
+	if snap.Networks, err = readNetworkStats(filepath.Join(hc.procDir, "net", "dev")); err != nil {
+		return hc.snapshot, err
+	}
+
+	snap.Utilization = cpuUtilization(hc.snapshot.CPU, snap.CPU)
+
+	hc.snapshot = snap
+	hc.readAt = time.Now()
+	return snap, nil
+}
+
+// cpuUtilization calcula a fração de tempo em cada modo entre duas leituras.
+// Sem leitura anterior, usa os tempos acumulados desde o boot.
+func cpuUtilization(prev, cur cpuTimes) cpuTimes {
+	delta := make(cpuTimes, len(cur))
+	for i := range cur {
+		delta[i] = cur[i]
+		if i < len(prev) {
+			delta[i] -= prev[i]
+		}
+	}
+
+	total := delta.total()
+	if total <= 0 {
+		return make(cpuTimes, len(cur))
+	}
+	for i := range delta {
+		delta[i] /= total
+	}
+	return delta
+}
+
+// readCPUStats lê a linha agregada "cpu" e conta as CPUs lógicas de /proc/stat,
+// convertendo os ticks em segundos com clockTicks.
+func readCPUStats(path string, clockTicks float64) (cpuTimes, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var times cpuTimes
+	var count int64
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		if fields[0] != "cpu" {
+			count++
+			continue
+		}
+		times = make(cpuTimes, len(cpuModes))
+		for i := range cpuModes {
+			if i+1 >= len(fields) {
+				break
+			}
+			ticks, _ := strconv.ParseUint(fields[i+1], 10, 64)
+			times[i] = float64(ticks) / clockTicks
+		}
+	}
+	if times == nil {
+		return nil, 0, fmt.Errorf("linha cpu não encontrada em %s", path)
+	}
+	return times, count, nil
+}
+
+// readMemoryStats lê os campos relevantes de /proc/meminfo.
+func readMemoryStats(path string) (memoryStats, error) {
+	var stats memoryStats
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return stats, err
+	}
+
+	fieldsByName := map[string]*int64{
+		"MemTotal":     &stats.Total,
+		"MemFree":      &stats.Free,
+		"MemAvailable": &stats.Available,
+		"Buffers":      &stats.Buffers,
+		"Cached":       &stats.Cached,
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		target, ok := fieldsByName[name]
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, _ := strconv.ParseInt(fields[0], 10, 64)
+		if len(fields) > 1 && fields[1] == "kB" {
+			value *= 1024
+		}
+		*target = value
+	}
+
+	if stats.Total == 0 {
+		return stats, fmt.Errorf("MemTotal não encontrado em %s", path)
+	}
+	// Kernels anteriores ao 3.14 não informam MemAvailable
+	if stats.Available == 0 {
+		stats.Available = stats.Free + stats.Buffers + stats.Cached
+	}
+	return stats, nil
+}
+
+// readDiskStats lê /proc/diskstats, ignorando dispositivos loop e ram e as partições
+// de discos listados (sda1, nvme0n1p1), cujos contadores já estão somados no disco.
+func readDiskStats(path string) ([]diskStats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []diskStats
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 13 {
+			continue
+		}
+		device := fields[2]
+		if strings.HasPrefix(device, "loop") || strings.HasPrefix(device, "ram") {
+			continue
+		}
+		parse := func(i int) int64 {
+			v, _ := strconv.ParseInt(fields[i], 10, 64)
+			return v
+		}
+		disks = append(disks, diskStats{
+			Device:     device,
+			Reads:      parse(3),
+			ReadBytes:  parse(5) * diskSectorSize,
+			Writes:     parse(7),
+			WriteBytes: parse(9) * diskSectorSize,
+			IOTime:     float64(parse(12)) / 1000,
+		})
+	}
+
+	devices := make(map[string]bool, len(disks))
+	for _, d := range disks {
+		devices[d.Device] = true
+	}
+	wholeDisks := disks[:0]
+	for _, d := range disks {
+		if !isPartition(d.Device, devices) {
+			wholeDisks = append(wholeDisks, d)
+		}
+	}
+	return wholeDisks, nil
+}
+
+// isPartition indica se device é uma partição de outro dispositivo em devices, no formato
+// <disco><n> (sda1) ou <disco>p<n> (nvme0n1p1, mmcblk0p1).
+func isPartition(device string, devices map[string]bool) bool {
+	base := strings.TrimRight(device, "0123456789")
+	if base == device || base == "" {
+		return false
+	}
+	if devices[base] {
+		return true
+	}
+	return strings.HasSuffix(base, "p") && devices[strings.TrimSuffix(base, "p")]
+}
+
+// readNetworkStats lê os contadores por interface de /proc/net/dev.
+func readNetworkStats(path string) ([]networkStats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []networkStats
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 12 {
+			continue
+		}
+		parse := func(i int) int64 {
+			v, _ := strconv.ParseInt(fields[i], 10, 64)
+			return v
+		}
+		networks = append(networks, networkStats{
+			Interface:       strings.TrimSpace(name),
+			ReceiveBytes:    parse(0),
+			ReceivePackets:  parse(1),
+			ReceiveErrors:   parse(2),
+			ReceiveDropped:  parse(3),
+			TransmitBytes:   parse(8),
+			TransmitPackets: parse(9),
+			TransmitErrors:  parse(10),
+			TransmitDropped: parse(11),
+		})
+	}
+	return networks, nil
+}
+
+// registerHostMetrics registra as métricas system.* do host lidas de procDir.
+// Retorna errHostMetricsUnavailable se procDir não contém as estatísticas do Linux.
+func registerHostMetrics(meter otelmetric.Meter, procDir string, cacheTTL time.Duration) (otelmetric.Registration, error) {
+	hc := newHostCollector(procDir, cacheTTL)
+	if _, err := hc.collect(); err != nil {
+		return nil, fmt.Errorf("%w: %v", errHostMetricsUnavailable, err)
+	}
+
+	cpuTime, err := meter.Float64ObservableCounter("system.cpu.time",
+		otelmetric.WithDescription("Tempo de CPU do host por modo"),
+		otelmetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cpuUtil, err := meter.Float64ObservableGauge("system.cpu.utilization",
+		otelmetric.WithDescription("Fração do tempo de CPU do host em cada modo desde a leitura anterior"),
+		otelmetric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cpuCount, err := meter.Int64ObservableUpDownCounter("system.cpu.logical.count",
+		otelmetric.WithDescription("Número de CPUs lógicas do host"),
+		otelmetric.WithUnit("{cpu}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memUsage, err := meter.Int64ObservableUpDownCounter("system.memory.usage",
+		otelmetric.WithDescription("Memória do host por estado; os estados somam a memória total"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memLimit, err := meter.Int64ObservableUpDownCounter("system.memory.limit",
+		otelmetric.WithDescription("Memória total do host"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memUtil, err := meter.Float64ObservableGauge("system.memory.utilization",
+		otelmetric.WithDescription("Fração da memória do host por estado"),
+		otelmetric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memAvailable, err := meter.Int64ObservableUpDownCounter("system.linux.memory.available",
+		otelmetric.WithDescription("Memória disponível para novos processos sem swap (MemAvailable)"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	diskIO, err := meter.Int64ObservableCounter("system.disk.io",
+		otelmetric.WithDescription("Bytes lidos e escritos por dispositivo"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	diskOps, err := meter.Int64ObservableCounter("system.disk.operations",
+		otelmetric.WithDescription("Operações de leitura e escrita concluídas por dispositivo"),
+		otelmetric.WithUnit("{operation}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	diskIOTime, err := meter.Float64ObservableCounter("system.disk.io_time",
+		otelmetric.WithDescription("Tempo em que o dispositivo teve operações em andamento"),
+		otelmetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	netIO, err := meter.Int64ObservableCounter("system.network.io",
+		otelmetric.WithDescription("Bytes recebidos e transmitidos por interface"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	netPackets, err := meter.Int64ObservableCounter("system.network.packets",
+		otelmetric.WithDescription("Pacotes recebidos e transmitidos por interface"),
+		otelmetric.WithUnit("{packet}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	netErrors, err := meter.Int64ObservableCounter("system.network.errors",
+		otelmetric.WithDescription("Erros de recepção e transmissão por interface"),
+		otelmetric.WithUnit("{error}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	netDropped, err := meter.Int64ObservableCounter("system.network.dropped",
+		otelmetric.WithDescription("Pacotes descartados por interface"),
+		otelmetric.WithUnit("{packet}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	modeAttrs := make([]otelmetric.ObserveOption, len(cpuModes))
+	for i, mode := range cpuModes {
+		modeAttrs[i] = otelmetric.WithAttributes(attribute.String("cpu.mode", mode))
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		snap, err := hc.collect()
+		if err != nil {
+			return fmt.Errorf("falha ao ler métricas de host: %w", err)
+		}
+
+		for i := range snap.CPU {
+			o.ObserveFloat64(cpuTime, snap.CPU[i], modeAttrs[i])
+			o.ObserveFloat64(cpuUtil, snap.Utilization[i], modeAttrs[i])
+		}
+		o.ObserveInt64(cpuCount, snap.CPUCount)
+
+		// Estados disjuntos, que somam Total; a memória recuperável do cache fica em
+		// system.linux.memory.available
+		mem := snap.Memory
+		used := max(mem.Total-mem.Free-mem.Buffers-mem.Cached, 0)
+		states := []struct {
+			state string
+			value int64
+		}{
+			{"used", used},
+			{"free", mem.Free},
+			{"buffers", mem.Buffers},
+			{"cached", mem.Cached},
+		}
+		for _, s := range states {
+			attrs := otelmetric.WithAttributes(attribute.String("system.memory.state", s.state))
+			o.ObserveInt64(memUsage, s.value, attrs)
+			o.ObserveFloat64(memUtil, float64(s.value)/float64(mem.Total), attrs)
+		}
+		o.ObserveInt64(memLimit, mem.Total)
+		o.ObserveInt64(memAvailable, mem.Available)
+
+		for _, d := range snap.Disks {
+			device := attribute.String("system.device", d.Device)
+			read := otelmetric.WithAttributes(device, attribute.String("disk.io.direction", "read"))
+			write := otelmetric.WithAttributes(device, attribute.String("disk.io.direction", "write"))
+			o.ObserveInt64(diskIO, d.ReadBytes, read)
+			o.ObserveInt64(diskIO, d.WriteBytes, write)
+			o.ObserveInt64(diskOps, d.Reads, read)
+			o.ObserveInt64(diskOps, d.Writes, write)
+			o.ObserveFloat64(diskIOTime, d.IOTime, otelmetric.WithAttributes(device))
+		}
+
+		for _, n := range snap.Networks {
+			iface := attribute.String("network.interface.name", n.Interface)
+			receive := otelmetric.WithAttributes(iface, attribute.String("network.io.direction", "receive"))
+			transmit := otelmetric.WithAttributes(iface, attribute.String("network.io.direction", "transmit"))
+			o.ObserveInt64(netIO, n.ReceiveBytes, receive)
+			o.ObserveInt64(netIO, n.TransmitBytes, transmit)
+			o.ObserveInt64(netPackets, n.ReceivePackets, receive)
+			o.ObserveInt64(netPackets, n.TransmitPackets, transmit)
+			o.ObserveInt64(netErrors, n.ReceiveErrors, receive)
+			o.ObserveInt64(netErrors, n.TransmitErrors, transmit)
+			o.ObserveInt64(netDropped, n.ReceiveDropped, receive)
+			o.ObserveInt64(netDropped, n.TransmitDropped, transmit)
+		}
+
+		return nil
+	}, cpuTime, cpuUtil, cpuCount, memUsage, memLimit, memUtil, memAvailable,
+		diskIO, diskOps, diskIOTime, netIO, netPackets, netErrors, netDropped)
+}
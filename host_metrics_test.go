@@ -0,0 +1,211 @@
+package graftel
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	fakeProcStat = `cpu  600 0 200 1000 100 0 0 100 0 0
+cpu0 300 0 100 500 50 0 0 50 0 0
+cpu1 300 0 100 500 50 0 0 50 0 0
+intr 1234 0 0
+`
+	fakeMeminfo = `MemTotal:        8000000 kB
+MemFree:         2000000 kB
+MemAvailable:    5000000 kB
+Buffers:          100000 kB
+Cached:          2500000 kB
+`
+	fakeDiskstats = `   7       0 loop0 10 0 80 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+   8       0 sda 100 5 2000 40 50 3 1000 30 0 1500 70 0 0 0 0 0 0
+   8       1 sda1 90 5 1800 35 45 3 900 25 0 1400 60 0 0 0 0 0 0
+`
+	fakeNetDev = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0:  500000    400    1    2    0     0          0         0   250000     300    3    4    0     0       0          0
+`
+)
+
+// writeFakeHostProc cria um /proc mínimo com as estatísticas de host.
+func writeFakeHostProc(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "net"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"stat":      fakeProcStat,
+		"meminfo":   fakeMeminfo,
+		"diskstats": fakeDiskstats,
+		"net/dev":   fakeNetDev,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestHostCollector_Collect(t *testing.T) {
+	hc := newHostCollector(writeFakeHostProc(t), time.Minute)
+
+	snap, err := hc.collect()
+	if err != nil {
+		t.Fatalf("collect() erro = %v", err)
+	}
+
+	if snap.CPUCount != 2 {
+		t.Errorf("CPUCount = %d, esperado 2", snap.CPUCount)
+	}
+	if snap.CPU[0] != 6 || snap.CPU[3] != 10 {
+		t.Errorf("CPU = %v, esperado user=6s idle=10s", snap.CPU)
+	}
+	if snap.Utilization[3] != 0.5 {
+		t.Errorf("utilização idle = %v, esperado 0.5", snap.Utilization[3])
+	}
+
+	if snap.Memory.Total != 8000000*1024 || snap.Memory.Available != 5000000*1024 {
+		t.Errorf("memória inesperada: %+v", snap.Memory)
+	}
+
+	if len(snap.Disks) != 1 || snap.Disks[0].Device != "sda" {
+		t.Fatalf("discos = %+v, esperado apenas sda", snap.Disks)
+	}
+	if d := snap.Disks[0]; d.ReadBytes != 2000*512 || d.Writes != 50 || d.IOTime != 1.5 {
+		t.Errorf("sda = %+v", d)
+	}
+
+	if len(snap.Networks) != 2 {
+		t.Fatalf("interfaces = %d, esperado 2", len(snap.Networks))
+	}
+	if n := snap.Networks[1]; n.Interface != "eth0" || n.TransmitBytes != 250000 || n.ReceiveDropped != 2 {
+		t.Errorf("eth0 = %+v", n)
+	}
+}
+
+func TestHostCollector_CachesWithinTTL(t *testing.T) {
+	dir := writeFakeHostProc(t)
+	hc := newHostCollector(dir, time.Hour)
+
+	if _, err := hc.collect(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "stat")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.collect(); err != nil {
+		t.Errorf("collect() dentro do TTL não deveria ler /proc: %v", err)
+	}
+}
+
+func TestCPUUtilization(t *testing.T) {
+	prev := cpuTimes{10, 0, 10, 80}
+	cur := cpuTimes{20, 0, 20, 100}
+
+	got := cpuUtilization(prev, cur)
+	want := cpuTimes{0.25, 0, 0.25, 0.5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("cpuUtilization()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := cpuUtilization(cur, cur); got.total() != 0 {
+		t.Errorf("sem variação deveria retornar zero, obtido %v", got)
+	}
+}
+
+func TestRegisterHostMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	reg, err := registerHostMetrics(provider.Meter("test"), writeFakeHostProc(t), time.Minute)
+	if err != nil {
+		t.Fatalf("registerHostMetrics() erro = %v", err)
+	}
+	defer reg.Unregister()
+
+	got := collectMetrics(t, reader)
+	for _, name := range []string{
+		"system.cpu.time", "system.cpu.utilization", "system.memory.usage",
+		"system.disk.io", "system.network.io",
+	} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("métrica %s não encontrada", name)
+		}
+	}
+
+	// Os estados de memória são disjuntos e somam system.memory.limit
+	var total int64
+	for _, dp := range got["system.memory.usage"].Data.(metricdata.Sum[int64]).DataPoints {
+		total += dp.Value
+	}
+	if limit := got["system.memory.limit"].Data.(metricdata.Sum[int64]).DataPoints[0].Value; total != limit {
+		t.Errorf("soma de system.memory.usage = %d, esperado %d", total, limit)
+	}
+	available := got["system.linux.memory.available"].Data.(metricdata.Sum[int64]).DataPoints[0].Value
+	if available != 5000000*1024 {
+		t.Errorf("system.linux.memory.available = %d", available)
+	}
+
+	netIO := got["system.network.io"].Data.(metricdata.Sum[int64])
+	if len(netIO.DataPoints) != 4 {
+		t.Errorf("system.network.io: esperado 4 pontos (2 interfaces x 2 direções), obtido %d", len(netIO.DataPoints))
+	}
+}
+
+func TestRegisterHostMetrics_WithoutProc(t *testing.T) {
+	provider := sdkmetric.NewMeterProvider()
+	if _, err := registerHostMetrics(provider.Meter("test"), t.TempDir(), time.Minute); !errors.Is(err, errHostMetricsUnavailable) {
+		t.Errorf("esperado errHostMetricsUnavailable sem /proc, obtido %v", err)
+	}
+}
+
+func TestIsPartition(t *testing.T) {
+	devices := map[string]bool{"sda": true, "sda1": true, "nvme0n1": true, "nvme0n1p2": true, "mmcblk0p1": true, "dm-0": true, "md127": true}
+	tests := map[string]bool{
+		"sda":       false,
+		"sda1":      true,
+		"nvme0n1":   false,
+		"nvme0n1p2": true,
+		"mmcblk0p1": false, // o disco mmcblk0 não está listado
+		"dm-0":      false,
+		"md127":     false,
+	}
+	for device, want := range tests {
+		if got := isPartition(device, devices); got != want {
+			t.Errorf("isPartition(%q) = %v, esperado %v", device, got, want)
+		}
+	}
+}
+
+func TestConfig_HostMetrics(t *testing.T) {
+	os.Setenv("GRAFTEL_HOST_METRICS", "true")
+	os.Setenv("GRAFTEL_HOST_METRICS_CACHE_TTL", "15s")
+	defer func() {
+		os.Unsetenv("GRAFTEL_HOST_METRICS")
+		os.Unsetenv("GRAFTEL_HOST_METRICS_CACHE_TTL")
+	}()
+
+	config := NewConfig("test-service")
+	if !config.HostMetrics || config.HostMetricsCacheTTL != 15*time.Second {
+		t.Errorf("HostMetrics = %v, HostMetricsCacheTTL = %v", config.HostMetrics, config.HostMetricsCacheTTL)
+	}
+
+	config = NewConfig("test-service").WithHostMetrics(0).WithMetricExportInterval(time.Minute)
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if config.HostMetricsCacheTTL != time.Minute {
+		t.Errorf("HostMetricsCacheTTL = %v, esperado MetricExportInterval", config.HostMetricsCacheTTL)
+	}
+}
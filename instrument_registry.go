@@ -0,0 +1,129 @@
+package graftel
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// instrumentKind identifica o tipo de um instrumento no registro.
+type instrumentKind string
+
+const (
+	kindCounter                  instrumentKind = "Counter"
+	kindUpDownCounter            instrumentKind = "UpDownCounter"
+	kindHistogram                instrumentKind = "Histogram"
+	kindFloat64Counter           instrumentKind = "Float64Counter"
+	kindFloat64UpDownCounter     instrumentKind = "Float64UpDownCounter"
+	kindInt64Histogram           instrumentKind = "Int64Histogram"
+	kindInt64Gauge               instrumentKind = "Int64Gauge"
+	kindFloat64Gauge             instrumentKind = "Float64Gauge"
+	kindGauge                    instrumentKind = "Gauge"
+	kindObservableCounter        instrumentKind = "ObservableCounter"
+	kindFloat64ObservableCounter instrumentKind = "Float64ObservableCounter"
+	kindObservableUpDownCounter  instrumentKind = "ObservableUpDownCounter"
+)
+
+// instrumentKey identifica um instrumento pelo escopo (nome do helper) e nome.
+type instrumentKey struct {
+	scope string
+	name  string
+}
+
+// instrumentSpec descreve um instrumento solicitado ao registro.
+type instrumentSpec struct {
+	name        string
+	kind        instrumentKind
+	description string
+	unit        string
+	// buckets são os limites explícitos de bucket de um histograma, formatados por formatBuckets.
+	buckets string
+	// contextTags são as chaves de tags do contexto anexadas às medições.
+	contextTags string
+	// hasCallback indica que o instrumento observável foi solicitado com callback próprio.
+	hasCallback bool
+}
+
+type registryEntry struct {
+	spec       instrumentSpec
+	instrument any
+}
+
+// instrumentRegistry guarda os instrumentos já criados para que chamadas repetidas
+// com o mesmo nome retornem o mesmo instrumento. É seguro para uso concorrente.
+type instrumentRegistry struct {
+	mu      sync.Mutex
+	entries map[instrumentKey]*registryEntry
+}
+
+func newInstrumentRegistry() *instrumentRegistry {
+	return &instrumentRegistry{entries: make(map[instrumentKey]*registryEntry)}
+}
+
+// getOrCreate retorna o instrumento registrado com o mesmo escopo e nome, ou o cria com create.
+// Retorna *ErrInstrumentConflict se o tipo, a descrição, a unidade ou os buckets divergirem do registrado,
+// ou se um instrumento observável já registrado for solicitado novamente com callback.
+// Com um registro nil, sempre cria um novo instrumento.
+func getOrCreate[T any](r *instrumentRegistry, scope string, spec instrumentSpec, create func() (T, error)) (T, error) {
+	if r == nil {
+		return create()
+	}
+
+	var zero T
+	key := instrumentKey{scope: scope, name: spec.name}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[key]; ok {
+		if err := entry.spec.conflict(spec); err != nil {
+			return zero, err
+		}
+		return entry.instrument.(T), nil
+	}
+
+	instrument, err := create()
+	if err != nil {
+		return zero, err
+	}
+	r.entries[key] = &registryEntry{spec: spec, instrument: instrument}
+	return instrument, nil
+}
+
+// conflict compara o instrumento registrado com uma nova solicitação.
+func (s instrumentSpec) conflict(requested instrumentSpec) error {
+	newConflict := func(field, existing, got string) error {
+		return &ErrInstrumentConflict{
+			Instrument: s.name,
+			Field:      field,
+			Existing:   existing,
+			Requested:  got,
+		}
+	}
+
+	switch {
+	case s.kind != requested.kind:
+		return newConflict("kind", string(s.kind), string(requested.kind))
+	case s.description != requested.description:
+		return newConflict("description", s.description, requested.description)
+	case s.unit != requested.unit:
+		return newConflict("unit", s.unit, requested.unit)
+	case s.buckets != requested.buckets:
+		return newConflict("buckets", s.buckets, requested.buckets)
+	case s.contextTags != requested.contextTags:
+		return newConflict("context_tags", s.contextTags, requested.contextTags)
+	case requested.hasCallback:
+		return newConflict("callback", "registrado", "novo callback; use RegisterCallback")
+	}
+	return nil
+}
+
+// formatBuckets formata os limites de bucket para comparação e mensagens de conflito.
+// Retorna "" quando nenhum limite explícito foi definido.
+func formatBuckets(buckets []float64) string {
+	parts := make([]string, len(buckets))
+	for i, b := range buckets {
+		parts[i] = strconv.FormatFloat(b, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
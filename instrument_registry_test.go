@@ -0,0 +1,169 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsHelper_ReturnsExistingInstrument(t *testing.T) {
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"))
+
+	first, err := helper.NewCounter("jobs_total", "Jobs processados")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := helper.NewCounter("jobs_total", "Jobs processados")
+	if err != nil {
+		t.Fatalf("NewCounter() repetido erro = %v", err)
+	}
+	if first != second {
+		t.Error("esperado o mesmo *Counter para o mesmo nome")
+	}
+
+	first.Increment(context.Background())
+	second.Increment(context.Background())
+
+	sum := collectMetrics(t, reader)["jobs_total"].Data.(metricdata.Sum[int64])
+	if sum.DataPoints[0].Value != 2 {
+		t.Errorf("jobs_total = %d, esperado 2", sum.DataPoints[0].Value)
+	}
+}
+
+func TestMetricsHelper_InstrumentConflicts(t *testing.T) {
+	helper := NewMetricsHelper(metric.NewMeterProvider().Meter("test"))
+
+	if _, err := helper.NewHistogram("latency", "Latência", otelmetric.WithUnit("s")); err != nil {
+		t.Fatal(err)
+	}
+
+	histogram := func(description, unit string) func() error {
+		return func() error {
+			_, err := helper.NewHistogram("latency", description, otelmetric.WithUnit(unit))
+			return err
+		}
+	}
+
+	tests := []struct {
+		name   string
+		create func() error
+		field  string
+	}{
+		{"tipo", func() error { _, err := helper.NewCounter("latency", "Latência"); return err }, "kind"},
+		{"descrição", histogram("Outra", "s"), "description"},
+		{"unidade", histogram("Latência", "ms"), "unit"},
+		{"buckets", func() error {
+			_, err := helper.NewHistogram("latency", "Latência", WithBuckets(0.1, 1), otelmetric.WithUnit("s"))
+			return err
+		}, "buckets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conflict *ErrInstrumentConflict
+			if err := tt.create(); !errors.As(err, &conflict) {
+				t.Fatalf("esperado *ErrInstrumentConflict, obtido %v", err)
+			}
+			if conflict.Field != tt.field || conflict.Instrument != "latency" {
+				t.Errorf("conflito inesperado: %+v", conflict)
+			}
+		})
+	}
+
+	if _, err := helper.NewHistogram("buckets", "test", WithBuckets(0.1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := helper.NewHistogram("buckets", "test", WithBuckets(0.1, 1)); err != nil {
+		t.Errorf("mesmos buckets não deveriam conflitar: %v", err)
+	}
+}
+
+func TestMetricsHelper_ObservableCallbackConflict(t *testing.T) {
+	helper := NewMetricsHelper(metric.NewMeterProvider().Meter("test")).(InstrumentsHelper)
+	callback := func(context.Context, otelmetric.Int64Observer) error { return nil }
+
+	first, err := helper.NewObservableCounter("events", "Eventos", callback)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := helper.NewObservableCounter("events", "Eventos", callback); err == nil {
+		t.Error("esperado erro ao registrar um segundo callback")
+	}
+
+	again, err := helper.NewObservableCounter("events", "Eventos", nil)
+	if err != nil {
+		t.Fatalf("NewObservableCounter() sem callback erro = %v", err)
+	}
+	if again != first {
+		t.Error("esperado o instrumento existente")
+	}
+}
+
+func TestMetricsHelper_ConcurrentCreation(t *testing.T) {
+	helper := NewMetricsHelper(metric.NewMeterProvider().Meter("test"))
+
+	const workers = 16
+	counters := make([]*Counter, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			counters[i], _ = helper.NewCounter("shared_total", "Compartilhado")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range counters {
+		if c == nil || c != counters[0] {
+			t.Fatal("todas as goroutines devem receber o mesmo instrumento")
+		}
+	}
+}
+
+func TestClient_MetricsHelpersShareRegistry(t *testing.T) {
+	client := createTestClientForMiddleware()
+	defer client.Shutdown(context.Background())
+
+	first, err := client.NewMetricsHelper("orders").NewCounter("orders_total", "Pedidos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.NewMetricsHelper("orders").NewCounter("orders_total", "Pedidos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("helpers do mesmo escopo devem compartilhar instrumentos")
+	}
+
+	other, err := client.NewMetricsHelper("billing").NewCounter("orders_total", "Outro escopo")
+	if err != nil {
+		t.Fatalf("escopos diferentes não devem conflitar: %v", err)
+	}
+	if other == first {
+		t.Error("escopos diferentes devem ter instrumentos distintos")
+	}
+}
+
+func TestMiddlewares_ShareHTTPInstruments(t *testing.T) {
+	errs := captureErrors(t)
+	client := createTestClientForMiddleware()
+	defer client.Shutdown(context.Background())
+
+	config := DefaultMiddlewareConfig("test-service")
+	HTTPMiddleware(client, config)
+	GinMiddleware(client, config)
+	EchoMiddleware(client, config)
+
+	if got := errs(); len(got) != 0 {
+		t.Errorf("esperado nenhum erro ao criar os middlewares, obtido %v", got)
+	}
+}
@@ -0,0 +1,94 @@
+// Package logtest fornece um exporter de logs em memória e um Client configurado com
+// ele, compartilhados pelos testes dos adaptadores de logging (graftelzap,
+// graftelzerolog e graftellogrus).
+package logtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CristianSsousa/graftel/v2"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Exporter é um sdklog.Exporter que guarda em memória os registros exportados.
+type Exporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+// Export implementa sdklog.Exporter.
+func (e *Exporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+// Shutdown implementa sdklog.Exporter.
+func (e *Exporter) Shutdown(context.Context) error { return nil }
+
+// ForceFlush implementa sdklog.Exporter.
+func (e *Exporter) ForceFlush(context.Context) error { return nil }
+
+// Records retorna os registros exportados até o momento, na ordem de exportação.
+func (e *Exporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+// NewClient cria e inicializa um Client cujos logs vão para o Exporter retornado.
+// Os logs passam pelo BatchProcessor com um intervalo de exportação longo: os registros
+// só chegam ao Exporter após um flush (veja Flush). Métricas e traces são enviados a um
+// servidor OTLP local que descarta os dados.
+func NewClient(t testing.TB) (graftel.Client, *Exporter) {
+	t.Helper()
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(sink.Close)
+
+	exporter := &Exporter{}
+	config := graftel.NewConfig("test").
+		WithOTLPEndpoint(sink.URL).
+		WithInsecure(true).
+		WithLogExporter(exporter).
+		WithLogExportInterval(time.Hour).
+		WithConsole(graftel.ConsoleConfig{Format: graftel.ConsoleFormatOff})
+
+	client, err := graftel.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client, exporter
+}
+
+// Flush envia os logs pendentes do client para o Exporter.
+func Flush(t testing.TB, client graftel.Client) {
+	t.Helper()
+	if err := client.(graftel.Flusher).ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Attributes retorna os atributos do registro indexados pela chave.
+func Attributes(record sdklog.Record) map[string]otellog.Value {
+	attrs := make(map[string]otellog.Value)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
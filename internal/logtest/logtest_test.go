@@ -0,0 +1,23 @@
+package logtest
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestNewClient_FlushDeliversRecords(t *testing.T) {
+	client, exporter := NewClient(t)
+	client.NewLogsHelper("test").Info(context.Background(), "pedido criado")
+
+	if got := exporter.Records(); len(got) != 0 {
+		t.Fatalf("nenhum registro deveria ser exportado antes do flush, obtidos %d", len(got))
+	}
+	Flush(t, client)
+
+	got := exporter.Records()
+	if len(got) != 1 || got[0].Severity() != otellog.SeverityInfo || got[0].Body().AsString() != "pedido criado" {
+		t.Errorf("esperado o registro no exporter após o flush, obtidos %d", len(got))
+	}
+}
@@ -4,7 +4,6 @@ package graftel
 import (
 	"context"
 	"fmt"
-	"os"
 	"runtime"
 	"strings"
 
@@ -39,7 +38,8 @@ type LogsHelper interface {
 	// Error envia um log de nível error.
 	Error(ctx context.Context, msg string, tags ...attribute.KeyValue)
 
-	// Fatal envia um log de nível fatal.
+	// Fatal envia um log de nível fatal. Em um LogsHelper do Client, faz o flush dos
+	// providers e encerra o processo (veja Config.DisableFatalExit).
 	Fatal(ctx context.Context, msg string, tags ...attribute.KeyValue)
 
 	// TraceWithFields envia um log trace com campos extras.
@@ -57,19 +57,43 @@ type LogsHelper interface {
 	// ErrorWithFields envia um log error com campos extras.
 	ErrorWithFields(ctx context.Context, msg string, fields map[string]interface{}, tags ...attribute.KeyValue)
 
-	// FatalWithFields envia um log fatal com campos extras.
+	// FatalWithFields envia um log fatal com campos extras e, como Fatal, encerra o processo.
 	FatalWithFields(ctx context.Context, msg string, fields map[string]interface{}, tags ...attribute.KeyValue)
 
 	// ErrorWithError envia um log de erro com uma mensagem de erro.
 	ErrorWithError(ctx context.Context, msg string, err error, tags ...attribute.KeyValue)
 }
 
+// LevelEnabler é implementado pelos LogsHelper criados pelo Client e por NewLogsHelper.
+// É uma interface opcional, para não quebrar implementações próprias de LogsHelper; use
+// LogEnabled para consultá-la.
+type LevelEnabler interface {
+	// Enabled informa se um log do nível seria emitido. Use para evitar montar campos
+	// custosos de logs que seriam descartados.
+	Enabled(ctx context.Context, level LogLevel) bool
+}
+
+// LogEnabled informa se logs emitiria um log do nível. LogsHelper que não implementam
+// LevelEnabler emitem todos os níveis.
+func LogEnabled(ctx context.Context, logs LogsHelper, level LogLevel) bool {
+	if enabler, ok := logs.(LevelEnabler); ok {
+		return enabler.Enabled(ctx, level)
+	}
+	return true
+}
+
 // logsHelper é a implementação concreta do LogsHelper.
 type logsHelper struct {
-	logger otellog.Logger
+	logger   otellog.Logger
+	name     string
+	minLevel LogLevel
+	console  *consoleWriter
+	// exit é chamado após Fatal e FatalWithFields; nil não encerra o processo.
+	exit func(ctx context.Context)
 }
 
-// NewLogsHelper cria um novo helper de logs.
+// NewLogsHelper cria um novo helper de logs. Sem um Client para o flush, Fatal apenas
+// emite o log; use Client.NewLogsHelper para encerrar o processo após Fatal.
 func NewLogsHelper(logger otellog.Logger) LogsHelper {
 	return &logsHelper{
 		logger: logger,
@@ -114,48 +138,116 @@ func (l LogLevel) String() string {
 	}
 }
 
-// Log envia um log com nível, mensagem e tags.
-func (l *logsHelper) Log(ctx context.Context, level LogLevel, msg string, tags ...attribute.KeyValue) {
-	severity := otellog.SeverityInfo
-	switch level {
+// ParseLogLevel converte um nome de nível ("trace", "debug", "info", "warn", "error" ou
+// "fatal", sem diferenciar maiúsculas) em LogLevel. "warning" é aceito como "warn".
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return LogLevelTrace, nil
+	case "debug":
+		return LogLevelDebug, nil
+	case "info":
+		return LogLevelInfo, nil
+	case "warn", "warning":
+		return LogLevelWarn, nil
+	case "error":
+		return LogLevelError, nil
+	case "fatal":
+		return LogLevelFatal, nil
+	default:
+		return LogLevelTrace, fmt.Errorf("nível de log desconhecido: %q", s)
+	}
+}
+
+// parseLogLevels interpreta GRAFTEL_LOG_LEVEL: entradas separadas por vírgula, onde
+// "nivel" define o nível global e "logger=nivel" um override por nome de logger.
+func parseLogLevels(s string) (LogLevel, map[string]LogLevel, error) {
+	global := LogLevelTrace
+	var overrides map[string]LogLevel
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, isOverride := strings.Cut(entry, "=")
+		if !isOverride {
+			value = name
+		}
+		level, err := ParseLogLevel(value)
+		if err != nil {
+			return LogLevelTrace, nil, err
+		}
+		if !isOverride {
+			global = level
+			continue
+		}
+		if overrides == nil {
+			overrides = make(map[string]LogLevel)
+		}
+		overrides[strings.TrimSpace(name)] = level
+	}
+	return global, overrides, nil
+}
+
+// valid informa se o nível é um dos LogLevel definidos.
+func (l LogLevel) valid() bool {
+	return l >= LogLevelTrace && l <= LogLevelFatal
+}
+
+// severity retorna a severidade OpenTelemetry correspondente ao nível.
+func (l LogLevel) severity() otellog.Severity {
+	switch l {
 	case LogLevelTrace:
-		severity = otellog.SeverityTrace
+		return otellog.SeverityTrace
 	case LogLevelDebug:
-		severity = otellog.SeverityDebug
-	case LogLevelInfo:
-		severity = otellog.SeverityInfo
+		return otellog.SeverityDebug
 	case LogLevelWarn:
-		severity = otellog.SeverityWarn
+		return otellog.SeverityWarn
 	case LogLevelError:
-		severity = otellog.SeverityError
+		return otellog.SeverityError
 	case LogLevelFatal:
-		severity = otellog.SeverityFatal
+		return otellog.SeverityFatal
+	default:
+		return otellog.SeverityInfo
+	}
+}
+
+// Enabled implementa LevelEnabler.
+func (l *logsHelper) Enabled(_ context.Context, level LogLevel) bool {
+	return level >= l.minLevel
+}
+
+// Log envia um log com nível, mensagem e tags.
+func (l *logsHelper) Log(ctx context.Context, level LogLevel, msg string, tags ...attribute.KeyValue) {
+	if !l.Enabled(ctx, level) {
+		return
 	}
 
 	// Criar record usando a API correta
 	record := otellog.Record{}
-	record.SetSeverity(severity)
+	record.SetSeverity(level.severity())
 
-	// Incluir tags no body da mensagem para garantir que apareçam no Loki
-	var bodyMsg string
+	// Também adicionar as tags como atributos para estruturação
 	if len(tags) > 0 {
-		// Formatar tags para incluir na mensagem
-		tagsStr := formatTags(tags)
-		bodyMsg = msg
-		if tagsStr != "" {
-			bodyMsg += " " + tagsStr
-		}
-		record.SetBody(otellog.StringValue(bodyMsg))
+		record.AddAttributes(convertAttributes(tags)...)
+	}
 
-		// Também adicionar como atributos para estruturação
-		logAttrs := convertAttributes(tags)
-		record.AddAttributes(logAttrs...)
-	} else {
-		record.SetBody(otellog.StringValue(msg))
+	l.emit(ctx, level, record, msg, formatTags(tags))
+}
+
+// emit completa o body do record, imprime o log no console e o envia via OpenTelemetry.
+// tagsStr é incluído no body e na linha do console. Os campos de trace do record são
+// preenchidos pelo SDK a partir do span no ctx.
+func (l *logsHelper) emit(ctx context.Context, level LogLevel, record otellog.Record, msg, tagsStr string) {
+	// Incluir tags no body da mensagem para garantir que apareçam no Loki
+	bodyMsg := msg
+	if tagsStr != "" {
+		bodyMsg += " " + tagsStr
 	}
+	record.SetBody(otellog.StringValue(bodyMsg))
 
-	// Imprimir no console no formato solicitado (antes de enviar para OpenTelemetry)
-	l.printFormattedLog(level, msg, tags)
+	// Imprimir no console no formato configurado (antes de enviar para OpenTelemetry)
+	l.console.print(ctx, level, l.name, record, msg, tagsStr)
 
 	// Emitir log via OpenTelemetry (sem impressão automática)
 	l.logger.Emit(ctx, record)
@@ -163,32 +255,63 @@ func (l *logsHelper) Log(ctx context.Context, level LogLevel, msg string, tags .
 
 // LogWithFields envia um log com campos extras formatados.
 func (l *logsHelper) LogWithFields(ctx context.Context, level LogLevel, msg string, fields map[string]interface{}, tags ...attribute.KeyValue) {
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
 	// Converter campos para atributos
 	allTags := make([]attribute.KeyValue, 0, len(fields)+len(tags))
 
 	for k, v := range fields {
-		switch val := v.(type) {
-		case string:
-			allTags = append(allTags, attribute.String(k, val))
-		case int:
-			allTags = append(allTags, attribute.Int(k, val))
-		case int64:
-			allTags = append(allTags, attribute.Int64(k, val))
-		case float64:
-			allTags = append(allTags, attribute.Float64(k, val))
-		case bool:
-			allTags = append(allTags, attribute.Bool(k, val))
-		default:
-			allTags = append(allTags, attribute.String(k, fmt.Sprintf("%v", val)))
-		}
+		allTags = append(allTags, FieldAttribute(k, v))
 	}
 
 	allTags = append(allTags, tags...)
 	l.Log(ctx, level, msg, allTags...)
 }
 
+// FieldAttribute converte um campo de log de tipo arbitrário em atributo. Strings,
+// inteiros, floats, bools e []string mantêm o tipo; os demais valores são formatados
+// com %v. É a conversão usada por LogWithFields e pelos adapters de zap, zerolog e logrus.
+func FieldAttribute(key string, value interface{}) attribute.KeyValue {
+	switch val := value.(type) {
+	case string:
+		return attribute.String(key, val)
+	case int:
+		return attribute.Int(key, val)
+	case int8:
+		return attribute.Int64(key, int64(val))
+	case int16:
+		return attribute.Int64(key, int64(val))
+	case int32:
+		return attribute.Int64(key, int64(val))
+	case int64:
+		return attribute.Int64(key, val)
+	case uint8:
+		return attribute.Int64(key, int64(val))
+	case uint16:
+		return attribute.Int64(key, int64(val))
+	case uint32:
+		return attribute.Int64(key, int64(val))
+	case float32:
+		return attribute.Float64(key, float64(val))
+	case float64:
+		return attribute.Float64(key, val)
+	case bool:
+		return attribute.Bool(key, val)
+	case []string:
+		return attribute.StringSlice(key, val)
+	default:
+		return attribute.String(key, fmt.Sprintf("%v", val))
+	}
+}
+
 // LogWithError envia um log de erro com uma mensagem de erro.
 func (l *logsHelper) LogWithError(ctx context.Context, level LogLevel, msg string, err error, tags ...attribute.KeyValue) {
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
 	errorTags := []attribute.KeyValue{
 		attribute.String("error", err.Error()),
 	}
@@ -224,6 +347,9 @@ func (l *logsHelper) Error(ctx context.Context, msg string, tags ...attribute.Ke
 // Fatal envia um log de nível fatal.
 func (l *logsHelper) Fatal(ctx context.Context, msg string, tags ...attribute.KeyValue) {
 	l.Log(ctx, LogLevelFatal, msg, tags...)
+	if l.exit != nil {
+		l.exit(ctx)
+	}
 }
 
 // TraceWithFields envia um log trace com campos extras.
@@ -254,6 +380,9 @@ func (l *logsHelper) ErrorWithFields(ctx context.Context, msg string, fields map
 // FatalWithFields envia um log fatal com campos extras.
 func (l *logsHelper) FatalWithFields(ctx context.Context, msg string, fields map[string]interface{}, tags ...attribute.KeyValue) {
 	l.LogWithFields(ctx, LogLevelFatal, msg, fields, tags...)
+	if l.exit != nil {
+		l.exit(ctx)
+	}
 }
 
 // ErrorWithError envia um log de erro com uma mensagem de erro.
@@ -303,26 +432,6 @@ func convertAttributes(tags []attribute.KeyValue) []otellog.KeyValue {
 	return logAttrs
 }
 
-func (l *logsHelper) printFormattedLog(level LogLevel, msg string, tags []attribute.KeyValue) {
-
-	tagsStr := formatTags(tags)
-
-	stacktrace := ""
-	if level == LogLevelError || level == LogLevelFatal {
-		stacktrace = getStackTrace()
-	}
-
-	logLine := msg
-	if tagsStr != "" {
-		logLine += " " + tagsStr
-	}
-	if stacktrace != "" {
-		logLine += " " + stacktrace
-	}
-
-	fmt.Fprintln(os.Stderr, logLine)
-}
-
 func formatTags(tags []attribute.KeyValue) string {
 	if len(tags) == 0 {
 		return ""
@@ -2,6 +2,7 @@ package graftel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func createTestLogger() otellog.Logger {
@@ -308,3 +310,173 @@ func TestLogsHelper_LogWithoutTags(t *testing.T) {
 
 	helper.Log(ctx, LogLevelInfo, "test message")
 }
+
+func TestFieldAttribute(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  attribute.Value
+	}{
+		{"texto", attribute.StringValue("texto")},
+		{42, attribute.IntValue(42)},
+		{int32(7), attribute.Int64Value(7)},
+		{uint16(8), attribute.Int64Value(8)},
+		{float32(1.5), attribute.Float64Value(1.5)},
+		{true, attribute.BoolValue(true)},
+		{[]string{"a", "b"}, attribute.StringSliceValue([]string{"a", "b"})},
+		{errors.New("falhou"), attribute.StringValue("falhou")},
+		{uint64(9), attribute.StringValue("9")},
+	}
+	for _, tt := range tests {
+		if got := FieldAttribute("campo", tt.value); got.Key != "campo" || got.Value != tt.want {
+			t.Errorf("FieldAttribute(%#v) = %v, esperado %v", tt.value, got.Value.Emit(), tt.want.Emit())
+		}
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  LogLevel
+	}{
+		{"trace", LogLevelTrace},
+		{"DEBUG", LogLevelDebug},
+		{" info ", LogLevelInfo},
+		{"warning", LogLevelWarn},
+		{"error", LogLevelError},
+		{"Fatal", LogLevelFatal},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogLevel(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = (%v, %v), esperado %v", tt.input, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLogLevel("verbose"); err == nil {
+		t.Error("ParseLogLevel deveria rejeitar nível desconhecido")
+	}
+}
+
+func TestParseLogLevels(t *testing.T) {
+	global, overrides, err := parseLogLevels("info, billing/http=warn,jobs=debug")
+	if err != nil {
+		t.Fatalf("parseLogLevels() erro = %v", err)
+	}
+	if global != LogLevelInfo {
+		t.Errorf("nível global = %v, esperado INFO", global)
+	}
+	if len(overrides) != 2 || overrides["billing/http"] != LogLevelWarn || overrides["jobs"] != LogLevelDebug {
+		t.Errorf("overrides = %v", overrides)
+	}
+
+	if _, _, err := parseLogLevels("info,billing=alto"); err == nil {
+		t.Error("parseLogLevels deveria rejeitar nível desconhecido")
+	}
+}
+
+func TestLogsHelper_Enabled(t *testing.T) {
+	c, recorder := newRecordingClient(t)
+	c.(*client).config = NewConfig("test").WithLogLevel(LogLevelWarn)
+	helper := c.NewLogsHelper("test")
+	ctx := context.Background()
+
+	if LogEnabled(ctx, helper, LogLevelInfo) || !LogEnabled(ctx, helper, LogLevelWarn) {
+		t.Error("LogEnabled deveria respeitar o nível mínimo WARN")
+	}
+	if NewContextLogger(helper, ctx).Enabled(LogLevelInfo) {
+		t.Error("ContextLogger.Enabled deveria respeitar o nível mínimo WARN")
+	}
+
+	helper.Info(ctx, "descartado")
+	helper.InfoWithFields(ctx, "descartado", map[string]interface{}{"a": 1})
+	helper.LogWithError(ctx, LogLevelDebug, "descartado", errors.New("falhou"))
+	helper.Error(ctx, "emitido")
+
+	records := recorder.all()
+	if len(records) != 1 || records[0].Body().AsString() != "emitido" {
+		t.Errorf("esperado apenas o log de ERROR, obtidos %d registros", len(records))
+	}
+}
+
+// customLogs é um LogsHelper próprio, sem o método Enabled.
+type customLogs struct{ LogsHelper }
+
+func TestLogEnabled_WithoutLevelEnabler(t *testing.T) {
+	var logs LogsHelper = customLogs{NewLogsHelper(createTestLogger())}
+	if _, ok := logs.(LevelEnabler); ok {
+		t.Fatal("customLogs não deveria implementar LevelEnabler")
+	}
+	if !LogEnabled(context.Background(), logs, LogLevelTrace) {
+		t.Error("LogsHelper sem LevelEnabler deveria emitir todos os níveis")
+	}
+}
+
+// newFatalClient cria um Client de teste cujo ExitFunc registra os códigos de saída.
+func newFatalClient(t *testing.T, config Config) (Client, *logRecorder, *[]int) {
+	t.Helper()
+	var codes []int
+	config = config.WithExitFunc(func(code int) { codes = append(codes, code) })
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	c, recorder := newRecordingClient(t)
+	c.(*client).config = config
+	return c, recorder, &codes
+}
+
+func TestLogsHelper_FatalExits(t *testing.T) {
+	c, recorder, codes := newFatalClient(t, NewConfig("test").WithFatalExitCode(3))
+	helper := c.NewLogsHelper("test")
+	ctx := context.Background()
+
+	helper.Fatal(ctx, "sem conexão")
+	if len(recorder.all()) != 1 || len(*codes) != 1 || (*codes)[0] != 3 {
+		t.Fatalf("Fatal deveria emitir o log e sair com código 3: registros=%d códigos=%v", len(recorder.all()), *codes)
+	}
+
+	helper.FatalWithFields(ctx, "sem conexão", map[string]interface{}{"tentativas": 3})
+	NewContextLogger(helper, ctx).Fatal("sem conexão")
+	if len(*codes) != 3 {
+		t.Errorf("FatalWithFields e ContextLogger.Fatal deveriam encerrar: códigos=%v", *codes)
+	}
+
+	// Log com nível fatal (usado pelos adapters e pelo slog) não encerra o processo
+	helper.Log(ctx, LogLevelFatal, "apenas log")
+	if len(*codes) != 3 {
+		t.Errorf("Log(LogLevelFatal) não deveria encerrar: códigos=%v", *codes)
+	}
+}
+
+func TestLogsHelper_FatalExitDisabled(t *testing.T) {
+	c, recorder, codes := newFatalClient(t, NewConfig("test").WithFatalExit(false))
+	c.NewLogsHelper("test").Fatal(context.Background(), "sem conexão")
+
+	if len(recorder.all()) != 1 || len(*codes) != 0 {
+		t.Errorf("com WithFatalExit(false), Fatal deveria apenas emitir o log: códigos=%v", *codes)
+	}
+}
+
+func TestLogsHelper_TraceCorrelation(t *testing.T) {
+	c, recorder := newRecordingClient(t)
+	helper := c.NewLogsHelper("test")
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	helper.Info(ctx, "dentro do span")
+	helper.Info(context.Background(), "fora do span")
+
+	records := recorder.all()
+	sc := span.SpanContext()
+	if records[0].TraceID() != sc.TraceID() || records[0].SpanID() != sc.SpanID() || records[0].TraceFlags() != sc.TraceFlags() {
+		t.Errorf("registro = trace %s span %s flags %s, esperado %s %s %s",
+			records[0].TraceID(), records[0].SpanID(), records[0].TraceFlags(), sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+	}
+	for _, key := range []string{"trace_id", "span_id", "trace_flags"} {
+		if _, ok := recordAttributes(records[0])[key]; ok {
+			t.Errorf("%s não deveria duplicar os campos de trace do registro como atributo", key)
+		}
+	}
+
+	if records[1].TraceID().IsValid() || records[1].SpanID().IsValid() {
+		t.Error("registro sem span não deveria ter trace_id nem span_id")
+	}
+}
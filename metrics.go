@@ -22,42 +22,133 @@ type MetricsHelper interface {
 	NewHistogram(name, description string, opts ...otelmetric.Float64HistogramOption) (*Histogram, error)
 
 	// NewGauge cria um novo gauge observável.
+	// Se callback for nil, os valores devem ser observados via InstrumentsHelper.RegisterCallback.
 	NewGauge(name, description string, callback func(context.Context, otelmetric.Float64Observer) error, opts ...otelmetric.Float64ObservableGaugeOption) (*Gauge, error)
 }
 
+// InstrumentsHelper estende o MetricsHelper com os demais tipos de instrumento e com a
+// observação em lote. Os helpers criados por NewMetricsHelper e Client.NewMetricsHelper
+// o implementam; para obtê-lo, use uma type assertion:
+//
+//	instruments, ok := metrics.(graftel.InstrumentsHelper)
+//
+// Ficar fora do MetricsHelper mantém compatíveis as implementações próprias da interface.
+type InstrumentsHelper interface {
+	MetricsHelper
+
+	// NewFloat64Counter cria um contador de valores fracionários (ex: bytes, valores monetários).
+	NewFloat64Counter(name, description string, opts ...otelmetric.Float64CounterOption) (*Float64Counter, error)
+
+	// NewFloat64UpDownCounter cria um contador de valores fracionários que pode incrementar ou decrementar.
+	NewFloat64UpDownCounter(name, description string, opts ...otelmetric.Float64UpDownCounterOption) (*Float64UpDownCounter, error)
+
+	// NewInt64Histogram cria um histograma de valores inteiros.
+	NewInt64Histogram(name, description string, opts ...otelmetric.Int64HistogramOption) (*Int64Histogram, error)
+
+	// NewInt64Gauge cria um gauge síncrono de valores inteiros.
+	NewInt64Gauge(name, description string, opts ...otelmetric.Int64GaugeOption) (*Int64Gauge, error)
+
+	// NewFloat64Gauge cria um gauge síncrono de valores fracionários.
+	NewFloat64Gauge(name, description string, opts ...otelmetric.Float64GaugeOption) (*Float64Gauge, error)
+
+	// NewObservableCounter cria um contador observável de valores inteiros.
+	// Se callback for nil, os valores devem ser observados via RegisterCallback.
+	NewObservableCounter(name, description string, callback func(context.Context, otelmetric.Int64Observer) error, opts ...otelmetric.Int64ObservableCounterOption) (*ObservableCounter, error)
+
+	// NewFloat64ObservableCounter cria um contador observável de valores fracionários.
+	// Se callback for nil, os valores devem ser observados via RegisterCallback.
+	NewFloat64ObservableCounter(name, description string, callback func(context.Context, otelmetric.Float64Observer) error, opts ...otelmetric.Float64ObservableCounterOption) (*Float64ObservableCounter, error)
+
+	// NewObservableUpDownCounter cria um up-down counter observável de valores inteiros.
+	// Se callback for nil, os valores devem ser observados via RegisterCallback.
+	NewObservableUpDownCounter(name, description string, callback func(context.Context, otelmetric.Int64Observer) error, opts ...otelmetric.Int64ObservableUpDownCounterOption) (*ObservableUpDownCounter, error)
+
+	// RegisterCallback registra um callback que observa vários instrumentos observáveis
+	// de uma só vez, útil quando os valores vêm de uma mesma leitura (ex: estatísticas de um pool).
+	// Use Unregister na Registration retornada para remover o callback.
+	RegisterCallback(callback otelmetric.Callback, instruments ...ObservableInstrument) (otelmetric.Registration, error)
+}
+
+// ObservableInstrument é um instrumento observável criado pelo MetricsHelper
+// (Gauge, ObservableCounter, Float64ObservableCounter ou ObservableUpDownCounter).
+type ObservableInstrument interface {
+	observable() otelmetric.Observable
+}
+
 // metricsHelper é a implementação concreta do MetricsHelper.
+// Instrumentos são registrados por nome: chamadas repetidas retornam o mesmo instrumento.
 type metricsHelper struct {
-	meter otelmetric.Meter
+	meter         otelmetric.Meter
+	scope         string
+	registry      *instrumentRegistry
+	cardinality   *cardinalityLimiter
+	tags          *contextTagFilter
+	strictSchemas bool
+}
+
+// MetricsHelperOption configura um MetricsHelper.
+type MetricsHelperOption func(*metricsHelper)
+
+// WithContextTags faz os instrumentos síncronos do helper anexarem a cada medição as tags
+// do contexto (veja WithTags) cujas chaves estão em keys. Atributos passados na medição
+// têm precedência sobre tags com a mesma chave. Instrumentos vinculados (Counter.Bind e
+// Histogram.Bind) fixam os atributos na criação e não recebem as tags do contexto; por
+// isso os contadores do SLOTracker também não as recebem.
+func WithContextTags(keys ...string) MetricsHelperOption {
+	return func(m *metricsHelper) {
+		m.tags = newContextTagFilter(keys)
+	}
+}
+
+// WithStrictSchemas faz as violações de schema de CounterVec, UpDownCounterVec e
+// HistogramVec criados com o helper causarem panic em vez de descartar a medição.
+// Indicado para testes, para que atributos inválidos falhem o teste.
+func WithStrictSchemas() MetricsHelperOption {
+	return func(m *metricsHelper) {
+		m.strictSchemas = true
+	}
 }
 
 // NewMetricsHelper cria um novo helper de métricas.
-func NewMetricsHelper(meter otelmetric.Meter) MetricsHelper {
-	return &metricsHelper{
-		meter: meter,
+// Helpers criados por Client.NewMetricsHelper compartilham o registro de instrumentos
+// do Client; helpers criados por esta função têm um registro próprio.
+func NewMetricsHelper(meter otelmetric.Meter, opts ...MetricsHelperOption) MetricsHelper {
+	m := &metricsHelper{
+		meter:    meter,
+		registry: newInstrumentRegistry(),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // Counter representa um contador de métricas.
 // Use NewCounter para criar uma instância.
 type Counter struct {
-	counter otelmetric.Int64Counter
+	counter     otelmetric.Int64Counter
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
 }
 
 // NewCounter cria um novo contador.
 func (m *metricsHelper) NewCounter(name, description string, opts ...otelmetric.Int64CounterOption) (*Counter, error) {
-	counter, err := m.meter.Int64Counter(name, append([]otelmetric.Int64CounterOption{
-		otelmetric.WithDescription(description),
-	}, opts...)...)
-	if err != nil {
-		return nil, err
-	}
+	opts = append([]otelmetric.Int64CounterOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewInt64CounterConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindCounter, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
 
-	return &Counter{counter: counter}, nil
+	return getOrCreate(m.registry, m.scope, spec, func() (*Counter, error) {
+		counter, err := m.meter.Int64Counter(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Counter{counter: counter, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
 }
 
 // Add incrementa o contador pelo valor especificado.
 func (c *Counter) Add(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
-	c.counter.Add(ctx, value, otelmetric.WithAttributes(attrs...))
+	c.counter.Add(ctx, value, otelmetric.WithAttributeSet(c.cardinality.admit(c.tags.merge(ctx, attrs))))
 }
 
 // Increment incrementa o contador em 1.
@@ -65,6 +156,33 @@ func (c *Counter) Increment(ctx context.Context, attrs ...attribute.KeyValue) {
 	c.Add(ctx, 1, attrs...)
 }
 
+// Float64Counter representa um contador de valores fracionários.
+type Float64Counter struct {
+	counter     otelmetric.Float64Counter
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+}
+
+// NewFloat64Counter cria um novo contador de valores fracionários.
+func (m *metricsHelper) NewFloat64Counter(name, description string, opts ...otelmetric.Float64CounterOption) (*Float64Counter, error) {
+	opts = append([]otelmetric.Float64CounterOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewFloat64CounterConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindFloat64Counter, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Float64Counter, error) {
+		counter, err := m.meter.Float64Counter(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Float64Counter{counter: counter, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
+}
+
+// Add incrementa o contador pelo valor especificado.
+func (c *Float64Counter) Add(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	c.counter.Add(ctx, value, otelmetric.WithAttributeSet(c.cardinality.admit(c.tags.merge(ctx, attrs))))
+}
+
 // Gauge representa um gauge de métricas observável.
 type Gauge struct {
 	gauge otelmetric.Float64ObservableGauge
@@ -72,37 +190,211 @@ type Gauge struct {
 
 // NewGauge cria um novo gauge observável.
 func (m *metricsHelper) NewGauge(name, description string, callback func(context.Context, otelmetric.Float64Observer) error, opts ...otelmetric.Float64ObservableGaugeOption) (*Gauge, error) {
-	gauge, err := m.meter.Float64ObservableGauge(name, append([]otelmetric.Float64ObservableGaugeOption{
-		otelmetric.WithDescription(description),
-		otelmetric.WithFloat64Callback(callback),
-	}, opts...)...)
-	if err != nil {
-		return nil, err
+	options := []otelmetric.Float64ObservableGaugeOption{otelmetric.WithDescription(description)}
+	if callback != nil {
+		options = append(options, otelmetric.WithFloat64Callback(callback))
+	}
+	options = append(options, opts...)
+	cfg := otelmetric.NewFloat64ObservableGaugeConfig(options...)
+	spec := instrumentSpec{name: name, kind: kindGauge, description: cfg.Description(), unit: cfg.Unit(), hasCallback: callback != nil}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Gauge, error) {
+		gauge, err := m.meter.Float64ObservableGauge(name, options...)
+		if err != nil {
+			return nil, err
+		}
+		return &Gauge{gauge: gauge}, nil
+	})
+}
+
+// Observe registra o valor do gauge em um callback de RegisterCallback.
+func (g *Gauge) Observe(o otelmetric.Observer, value float64, attrs ...attribute.KeyValue) {
+	o.ObserveFloat64(g.gauge, value, otelmetric.WithAttributes(attrs...))
+}
+
+func (g *Gauge) observable() otelmetric.Observable { return g.gauge }
+
+// Int64Gauge representa um gauge síncrono de valores inteiros.
+type Int64Gauge struct {
+	gauge       otelmetric.Int64Gauge
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+}
+
+// NewInt64Gauge cria um novo gauge síncrono de valores inteiros.
+func (m *metricsHelper) NewInt64Gauge(name, description string, opts ...otelmetric.Int64GaugeOption) (*Int64Gauge, error) {
+	opts = append([]otelmetric.Int64GaugeOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewInt64GaugeConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindInt64Gauge, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Int64Gauge, error) {
+		gauge, err := m.meter.Int64Gauge(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Int64Gauge{gauge: gauge, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
+}
+
+// Record registra o valor atual do gauge.
+func (g *Int64Gauge) Record(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
+	g.gauge.Record(ctx, value, otelmetric.WithAttributeSet(g.cardinality.admit(g.tags.merge(ctx, attrs))))
+}
+
+// Float64Gauge representa um gauge síncrono de valores fracionários.
+type Float64Gauge struct {
+	gauge       otelmetric.Float64Gauge
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+}
+
+// NewFloat64Gauge cria um novo gauge síncrono de valores fracionários.
+func (m *metricsHelper) NewFloat64Gauge(name, description string, opts ...otelmetric.Float64GaugeOption) (*Float64Gauge, error) {
+	opts = append([]otelmetric.Float64GaugeOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewFloat64GaugeConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindFloat64Gauge, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Float64Gauge, error) {
+		gauge, err := m.meter.Float64Gauge(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Float64Gauge{gauge: gauge, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
+}
+
+// Record registra o valor atual do gauge.
+func (g *Float64Gauge) Record(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	g.gauge.Record(ctx, value, otelmetric.WithAttributeSet(g.cardinality.admit(g.tags.merge(ctx, attrs))))
+}
+
+// ObservableCounter representa um contador observável de valores inteiros.
+type ObservableCounter struct {
+	counter otelmetric.Int64ObservableCounter
+}
+
+// NewObservableCounter cria um novo contador observável.
+func (m *metricsHelper) NewObservableCounter(name, description string, callback func(context.Context, otelmetric.Int64Observer) error, opts ...otelmetric.Int64ObservableCounterOption) (*ObservableCounter, error) {
+	options := []otelmetric.Int64ObservableCounterOption{otelmetric.WithDescription(description)}
+	if callback != nil {
+		options = append(options, otelmetric.WithInt64Callback(callback))
+	}
+	options = append(options, opts...)
+	cfg := otelmetric.NewInt64ObservableCounterConfig(options...)
+	spec := instrumentSpec{name: name, kind: kindObservableCounter, description: cfg.Description(), unit: cfg.Unit(), hasCallback: callback != nil}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*ObservableCounter, error) {
+		counter, err := m.meter.Int64ObservableCounter(name, options...)
+		if err != nil {
+			return nil, err
+		}
+		return &ObservableCounter{counter: counter}, nil
+	})
+}
+
+// Observe registra o valor acumulado do contador em um callback de RegisterCallback.
+func (c *ObservableCounter) Observe(o otelmetric.Observer, value int64, attrs ...attribute.KeyValue) {
+	o.ObserveInt64(c.counter, value, otelmetric.WithAttributes(attrs...))
+}
+
+func (c *ObservableCounter) observable() otelmetric.Observable { return c.counter }
+
+// Float64ObservableCounter representa um contador observável de valores fracionários.
+type Float64ObservableCounter struct {
+	counter otelmetric.Float64ObservableCounter
+}
+
+// NewFloat64ObservableCounter cria um novo contador observável de valores fracionários.
+func (m *metricsHelper) NewFloat64ObservableCounter(name, description string, callback func(context.Context, otelmetric.Float64Observer) error, opts ...otelmetric.Float64ObservableCounterOption) (*Float64ObservableCounter, error) {
+	options := []otelmetric.Float64ObservableCounterOption{otelmetric.WithDescription(description)}
+	if callback != nil {
+		options = append(options, otelmetric.WithFloat64Callback(callback))
+	}
+	options = append(options, opts...)
+	cfg := otelmetric.NewFloat64ObservableCounterConfig(options...)
+	spec := instrumentSpec{name: name, kind: kindFloat64ObservableCounter, description: cfg.Description(), unit: cfg.Unit(), hasCallback: callback != nil}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Float64ObservableCounter, error) {
+		counter, err := m.meter.Float64ObservableCounter(name, options...)
+		if err != nil {
+			return nil, err
+		}
+		return &Float64ObservableCounter{counter: counter}, nil
+	})
+}
+
+// Observe registra o valor acumulado do contador em um callback de RegisterCallback.
+func (c *Float64ObservableCounter) Observe(o otelmetric.Observer, value float64, attrs ...attribute.KeyValue) {
+	o.ObserveFloat64(c.counter, value, otelmetric.WithAttributes(attrs...))
+}
+
+func (c *Float64ObservableCounter) observable() otelmetric.Observable { return c.counter }
+
+// ObservableUpDownCounter representa um up-down counter observável de valores inteiros.
+type ObservableUpDownCounter struct {
+	counter otelmetric.Int64ObservableUpDownCounter
+}
+
+// NewObservableUpDownCounter cria um novo up-down counter observável.
+func (m *metricsHelper) NewObservableUpDownCounter(name, description string, callback func(context.Context, otelmetric.Int64Observer) error, opts ...otelmetric.Int64ObservableUpDownCounterOption) (*ObservableUpDownCounter, error) {
+	options := []otelmetric.Int64ObservableUpDownCounterOption{otelmetric.WithDescription(description)}
+	if callback != nil {
+		options = append(options, otelmetric.WithInt64Callback(callback))
 	}
+	options = append(options, opts...)
+	cfg := otelmetric.NewInt64ObservableUpDownCounterConfig(options...)
+	spec := instrumentSpec{name: name, kind: kindObservableUpDownCounter, description: cfg.Description(), unit: cfg.Unit(), hasCallback: callback != nil}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*ObservableUpDownCounter, error) {
+		counter, err := m.meter.Int64ObservableUpDownCounter(name, options...)
+		if err != nil {
+			return nil, err
+		}
+		return &ObservableUpDownCounter{counter: counter}, nil
+	})
+}
+
+// Observe registra o valor atual do contador em um callback de RegisterCallback.
+func (u *ObservableUpDownCounter) Observe(o otelmetric.Observer, value int64, attrs ...attribute.KeyValue) {
+	o.ObserveInt64(u.counter, value, otelmetric.WithAttributes(attrs...))
+}
+
+func (u *ObservableUpDownCounter) observable() otelmetric.Observable { return u.counter }
 
-	return &Gauge{gauge: gauge}, nil
+// RegisterCallback registra um callback que observa vários instrumentos de uma só vez.
+func (m *metricsHelper) RegisterCallback(callback otelmetric.Callback, instruments ...ObservableInstrument) (otelmetric.Registration, error) {
+	observables := make([]otelmetric.Observable, len(instruments))
+	for i, inst := range instruments {
+		observables[i] = inst.observable()
+	}
+	return m.meter.RegisterCallback(callback, observables...)
 }
 
 // UpDownCounter representa um contador que pode incrementar ou decrementar.
 type UpDownCounter struct {
-	counter otelmetric.Int64UpDownCounter
+	counter     otelmetric.Int64UpDownCounter
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
 }
 
 // NewUpDownCounter cria um novo up-down counter.
 func (m *metricsHelper) NewUpDownCounter(name, description string, opts ...otelmetric.Int64UpDownCounterOption) (*UpDownCounter, error) {
-	counter, err := m.meter.Int64UpDownCounter(name, append([]otelmetric.Int64UpDownCounterOption{
-		otelmetric.WithDescription(description),
-	}, opts...)...)
-	if err != nil {
-		return nil, err
-	}
+	opts = append([]otelmetric.Int64UpDownCounterOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewInt64UpDownCounterConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindUpDownCounter, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
 
-	return &UpDownCounter{counter: counter}, nil
+	return getOrCreate(m.registry, m.scope, spec, func() (*UpDownCounter, error) {
+		counter, err := m.meter.Int64UpDownCounter(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &UpDownCounter{counter: counter, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
 }
 
 // Add adiciona (ou subtrai) um valor ao contador.
 func (u *UpDownCounter) Add(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
-	u.counter.Add(ctx, value, otelmetric.WithAttributes(attrs...))
+	u.counter.Add(ctx, value, otelmetric.WithAttributeSet(u.cardinality.admit(u.tags.merge(ctx, attrs))))
 }
 
 // Increment incrementa o contador em 1.
@@ -115,29 +407,117 @@ func (u *UpDownCounter) Decrement(ctx context.Context, attrs ...attribute.KeyVal
 	u.Add(ctx, -1, attrs...)
 }
 
+// Float64UpDownCounter representa um contador de valores fracionários que pode incrementar ou decrementar.
+type Float64UpDownCounter struct {
+	counter     otelmetric.Float64UpDownCounter
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+}
+
+// NewFloat64UpDownCounter cria um novo up-down counter de valores fracionários.
+func (m *metricsHelper) NewFloat64UpDownCounter(name, description string, opts ...otelmetric.Float64UpDownCounterOption) (*Float64UpDownCounter, error) {
+	opts = append([]otelmetric.Float64UpDownCounterOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewFloat64UpDownCounterConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindFloat64UpDownCounter, description: cfg.Description(), unit: cfg.Unit(), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Float64UpDownCounter, error) {
+		counter, err := m.meter.Float64UpDownCounter(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Float64UpDownCounter{counter: counter, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
+}
+
+// Add adiciona (ou subtrai) um valor ao contador.
+func (u *Float64UpDownCounter) Add(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	u.counter.Add(ctx, value, otelmetric.WithAttributeSet(u.cardinality.admit(u.tags.merge(ctx, attrs))))
+}
+
 // Histogram representa um histograma de métricas.
 type Histogram struct {
-	histogram otelmetric.Float64Histogram
+	histogram   otelmetric.Float64Histogram
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+	unit        DurationUnit
 }
 
 // NewHistogram cria um novo histograma.
 func (m *metricsHelper) NewHistogram(name, description string, opts ...otelmetric.Float64HistogramOption) (*Histogram, error) {
-	histogram, err := m.meter.Float64Histogram(name, append([]otelmetric.Float64HistogramOption{
-		otelmetric.WithDescription(description),
-	}, opts...)...)
-	if err != nil {
-		return nil, err
+	opts = append([]otelmetric.Float64HistogramOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewFloat64HistogramConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindHistogram, description: cfg.Description(), unit: cfg.Unit(), buckets: formatBuckets(cfg.ExplicitBucketBoundaries()), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Histogram, error) {
+		histogram, err := m.meter.Float64Histogram(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Histogram{
+			histogram:   histogram,
+			cardinality: m.cardinality.forInstrument(m.scope, name),
+			tags:        m.tags,
+			unit:        durationUnitOf(cfg.Unit()),
+		}, nil
+	})
+}
+
+// WithBuckets define limites de bucket explícitos para NewHistogram ou NewInt64Histogram
+// (ex: WithBuckets(DurationBuckets...)). Views configuradas em Config têm precedência
+// sobre esses limites.
+func WithBuckets(buckets ...float64) otelmetric.HistogramOption {
+	return otelmetric.WithExplicitBucketBoundaries(buckets...)
+}
+
+// NewDurationHistogram cria em metrics um histograma de durações na unidade informada, com
+// DurationBuckets convertidos para essa unidade. RecordDuration, StartTimer e Measure
+// registram os valores na mesma unidade.
+func NewDurationHistogram(m MetricsHelper, name, description string, unit DurationUnit, opts ...otelmetric.Float64HistogramOption) (*Histogram, error) {
+	buckets := make([]float64, len(DurationBuckets))
+	for i, b := range DurationBuckets {
+		buckets[i] = b * unit.perSecond()
 	}
 
-	return &Histogram{histogram: histogram}, nil
+	return m.NewHistogram(name, description, append([]otelmetric.Float64HistogramOption{
+		WithBuckets(buckets...),
+		otelmetric.WithUnit(string(unit)),
+	}, opts...)...)
 }
 
 // Record registra um valor no histograma.
 func (h *Histogram) Record(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
-	h.histogram.Record(ctx, value, otelmetric.WithAttributes(attrs...))
+	h.histogram.Record(ctx, value, otelmetric.WithAttributeSet(h.cardinality.admit(h.tags.merge(ctx, attrs))))
 }
 
-// RecordDuration registra uma duração no histograma (em segundos).
+// RecordDuration registra uma duração no histograma, em segundos ou em milissegundos
+// quando a unidade do instrumento é "ms".
 func (h *Histogram) RecordDuration(ctx context.Context, duration time.Duration, attrs ...attribute.KeyValue) {
-	h.Record(ctx, duration.Seconds(), attrs...)
+	h.Record(ctx, h.unit.convert(duration), attrs...)
+}
+
+// Int64Histogram representa um histograma de valores inteiros.
+type Int64Histogram struct {
+	histogram   otelmetric.Int64Histogram
+	cardinality *instrumentCardinality
+	tags        *contextTagFilter
+}
+
+// NewInt64Histogram cria um novo histograma de valores inteiros.
+func (m *metricsHelper) NewInt64Histogram(name, description string, opts ...otelmetric.Int64HistogramOption) (*Int64Histogram, error) {
+	opts = append([]otelmetric.Int64HistogramOption{otelmetric.WithDescription(description)}, opts...)
+	cfg := otelmetric.NewInt64HistogramConfig(opts...)
+	spec := instrumentSpec{name: name, kind: kindInt64Histogram, description: cfg.Description(), unit: cfg.Unit(), buckets: formatBuckets(cfg.ExplicitBucketBoundaries()), contextTags: m.tags.String()}
+
+	return getOrCreate(m.registry, m.scope, spec, func() (*Int64Histogram, error) {
+		histogram, err := m.meter.Int64Histogram(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &Int64Histogram{histogram: histogram, cardinality: m.cardinality.forInstrument(m.scope, name), tags: m.tags}, nil
+	})
+}
+
+// Record registra um valor no histograma.
+func (h *Int64Histogram) Record(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
+	h.histogram.Record(ctx, value, otelmetric.WithAttributeSet(h.cardinality.admit(h.tags.merge(ctx, attrs))))
 }
@@ -8,6 +8,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	otelmetric "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -311,3 +312,183 @@ func TestUpDownCounter_WithAttributes(t *testing.T) {
 	counter.Increment(ctx, attrs...)
 	counter.Decrement(ctx, attrs...)
 }
+
+func TestWithBuckets(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithResource(resource.Empty()),
+	)
+	helper := NewMetricsHelper(meterProvider.Meter("test")).(InstrumentsHelper)
+
+	histogram, err := helper.NewHistogram("test_duration", "Test", WithBuckets(DurationBuckets...))
+	if err != nil {
+		t.Fatalf("NewHistogram(WithBuckets) erro = %v", err)
+	}
+	histogram.Record(context.Background(), 0.02)
+	sizes, err := helper.NewInt64Histogram("test_size", "Test", WithBuckets(10, 100))
+	if err != nil {
+		t.Fatalf("NewInt64Histogram(WithBuckets) erro = %v", err)
+	}
+	sizes.Record(context.Background(), 42)
+
+	metrics := collectMetrics(t, reader)
+	data := metrics["test_duration"].Data.(metricdata.Histogram[float64])
+	if len(data.DataPoints[0].Bounds) != len(DurationBuckets) {
+		t.Errorf("esperado %d limites, obtido %v", len(DurationBuckets), data.DataPoints[0].Bounds)
+	}
+	if bounds := metrics["test_size"].Data.(metricdata.Histogram[int64]).DataPoints[0].Bounds; len(bounds) != 2 {
+		t.Errorf("esperado 2 limites, obtido %v", bounds)
+	}
+}
+
+func TestMetricsHelper_SynchronousInstruments(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithResource(resource.Empty()),
+	)
+	helper := NewMetricsHelper(meterProvider.Meter("test")).(InstrumentsHelper)
+	ctx := context.Background()
+
+	bytesSent, err := helper.NewFloat64Counter("bytes_sent", "Bytes enviados", otelmetric.WithUnit("By"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytesSent.Add(ctx, 1.5)
+	bytesSent.Add(ctx, 2.5)
+
+	balance, err := helper.NewFloat64UpDownCounter("balance", "Saldo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance.Add(ctx, 10.5)
+	balance.Add(ctx, -0.5)
+
+	payload, err := helper.NewInt64Histogram("payload_size", "Tamanho do payload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload.Record(ctx, 512)
+
+	queueDepth, err := helper.NewInt64Gauge("queue_depth", "Profundidade da fila")
+	if err != nil {
+		t.Fatal(err)
+	}
+	queueDepth.Record(ctx, 5)
+	queueDepth.Record(ctx, 3)
+
+	temperature, err := helper.NewFloat64Gauge("temperature", "Temperatura")
+	if err != nil {
+		t.Fatal(err)
+	}
+	temperature.Record(ctx, 21.5)
+
+	got := collectMetrics(t, reader)
+
+	if v := got["bytes_sent"].Data.(metricdata.Sum[float64]).DataPoints[0].Value; v != 4 {
+		t.Errorf("bytes_sent = %v, esperado 4", v)
+	}
+	if v := got["balance"].Data.(metricdata.Sum[float64]).DataPoints[0].Value; v != 10 {
+		t.Errorf("balance = %v, esperado 10", v)
+	}
+	if v := got["payload_size"].Data.(metricdata.Histogram[int64]).DataPoints[0].Sum; v != 512 {
+		t.Errorf("payload_size soma = %v, esperado 512", v)
+	}
+	if v := got["queue_depth"].Data.(metricdata.Gauge[int64]).DataPoints[0].Value; v != 3 {
+		t.Errorf("queue_depth = %v, esperado o último valor 3", v)
+	}
+	if v := got["temperature"].Data.(metricdata.Gauge[float64]).DataPoints[0].Value; v != 21.5 {
+		t.Errorf("temperature = %v, esperado 21.5", v)
+	}
+}
+
+func TestMetricsHelper_ObservableInstruments(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithResource(resource.Empty()),
+	)
+	helper := NewMetricsHelper(meterProvider.Meter("test")).(InstrumentsHelper)
+
+	processed, err := helper.NewObservableCounter("jobs_processed", "Jobs processados",
+		func(_ context.Context, o otelmetric.Int64Observer) error {
+			o.Observe(42)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processed == nil {
+		t.Fatal("NewObservableCounter() retornou nil")
+	}
+
+	got := collectMetrics(t, reader)
+	if v := got["jobs_processed"].Data.(metricdata.Sum[int64]).DataPoints[0].Value; v != 42 {
+		t.Errorf("jobs_processed = %v, esperado 42", v)
+	}
+}
+
+func TestMetricsHelper_RegisterCallback(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithResource(resource.Empty()),
+	)
+	helper := NewMetricsHelper(meterProvider.Meter("test")).(InstrumentsHelper)
+
+	open, err := helper.NewObservableUpDownCounter("pool_connections_open", "Conexões abertas", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waited, err := helper.NewFloat64ObservableCounter("pool_wait_seconds", "Tempo de espera", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := helper.NewGauge("pool_usage_ratio", "Uso do pool", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquired, err := helper.NewObservableCounter("pool_acquired", "Conexões adquiridas", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	reg, err := helper.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		calls++
+		open.Observe(o, 8, attribute.String("pool", "primary"))
+		waited.Observe(o, 1.25, attribute.String("pool", "primary"))
+		usage.Observe(o, 0.8, attribute.String("pool", "primary"))
+		acquired.Observe(o, 100, attribute.String("pool", "primary"))
+		return nil
+	}, open, waited, usage, acquired)
+	if err != nil {
+		t.Fatalf("RegisterCallback() erro = %v", err)
+	}
+
+	got := collectMetrics(t, reader)
+	if calls != 1 {
+		t.Errorf("callback chamado %d vezes, esperado 1 por coleta", calls)
+	}
+	if v := got["pool_connections_open"].Data.(metricdata.Sum[int64]).DataPoints[0].Value; v != 8 {
+		t.Errorf("pool_connections_open = %v, esperado 8", v)
+	}
+	if v := got["pool_wait_seconds"].Data.(metricdata.Sum[float64]).DataPoints[0].Value; v != 1.25 {
+		t.Errorf("pool_wait_seconds = %v, esperado 1.25", v)
+	}
+	if v := got["pool_usage_ratio"].Data.(metricdata.Gauge[float64]).DataPoints[0].Value; v != 0.8 {
+		t.Errorf("pool_usage_ratio = %v, esperado 0.8", v)
+	}
+	if v := got["pool_acquired"].Data.(metricdata.Sum[int64]).DataPoints[0].Value; v != 100 {
+		t.Errorf("pool_acquired = %v, esperado 100", v)
+	}
+
+	if err := reg.Unregister(); err != nil {
+		t.Fatalf("Unregister() erro = %v", err)
+	}
+	collectMetrics(t, reader)
+	if calls != 1 {
+		t.Error("callback não deveria ser chamado após Unregister")
+	}
+}
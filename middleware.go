@@ -7,8 +7,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/noop"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -19,6 +21,8 @@ type MiddlewareConfig struct {
 	RecordRequestBody  bool
 	RecordResponseBody bool
 	MaxBodySize        int64
+	// SLOs recebe cada requisição instrumentada para calcular os SLOs declarados. Opcional.
+	SLOs *SLOTracker
 }
 
 func DefaultMiddlewareConfig(serviceName string) MiddlewareConfig {
@@ -40,15 +44,47 @@ func shouldSkip(path string, skipPaths []string) bool {
 	return false
 }
 
+// newHTTPMetrics obtém os instrumentos compartilhados pelos middlewares HTTP.
+// Como o MetricsHelper do Client reaproveita instrumentos pelo nome, vários middlewares
+// registram nos mesmos instrumentos. Erros são reportados via otel.Handle e o instrumento
+// afetado passa a ser no-op, para que o middleware continue funcionando.
+func newHTTPMetrics(metrics MetricsHelper) (requests *Counter, duration, requestSize, responseSize *Histogram) {
+	fallback := NewMetricsHelper(noop.NewMeterProvider().Meter(""))
+
+	var err error
+	if requests, err = metrics.NewCounter("http_requests_total", "Total de requisições HTTP"); err != nil {
+		otel.Handle(err)
+		requests, _ = fallback.NewCounter("http_requests_total", "")
+	}
+
+	histogram := func(name, description string, buckets []float64) *Histogram {
+		var h *Histogram
+		var err error
+		if buckets != nil {
+			h, err = metrics.NewHistogram(name, description, WithBuckets(buckets...))
+		} else {
+			h, err = metrics.NewHistogram(name, description)
+		}
+		if err != nil {
+			otel.Handle(err)
+			h, _ = fallback.NewHistogram(name, "")
+		}
+		return h
+	}
+
+	duration = histogram("http_request_duration_seconds", "Duração das requisições HTTP em segundos", DurationBuckets)
+	requestSize = histogram("http_request_size_bytes", "Tamanho das requisições HTTP em bytes", nil)
+	responseSize = histogram("http_response_size_bytes", "Tamanho das respostas HTTP em bytes", nil)
+
+	return requests, duration, requestSize, responseSize
+}
+
 func HTTPMiddleware(client Client, config MiddlewareConfig) func(http.Handler) http.Handler {
 	tracing := client.NewTracingHelper(config.ServiceName)
 	metrics := client.NewMetricsHelper(config.ServiceName + "/http")
 	logs := client.NewLogsHelper(config.ServiceName + "/http")
 
-	requestCounter, _ := metrics.NewCounter("http_requests_total", "Total de requisições HTTP")
-	requestDuration, _ := metrics.NewHistogram("http_request_duration_seconds", "Duração das requisições HTTP em segundos")
-	requestSize, _ := metrics.NewHistogram("http_request_size_bytes", "Tamanho das requisições HTTP em bytes")
-	responseSize, _ := metrics.NewHistogram("http_response_size_bytes", "Tamanho das respostas HTTP em bytes")
+	requestCounter, requestDuration, requestSize, responseSize := newHTTPMetrics(metrics)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -132,6 +168,8 @@ func HTTPMiddleware(client Client, config MiddlewareConfig) func(http.Handler) h
 				attribute.Int("status", statusCode),
 			)
 
+			config.SLOs.ObserveRequest(ctx, r.Method, r.URL.Path, statusCode, duration)
+
 			ctxLogger.Info("Requisição finalizada",
 				attribute.String("method", r.Method),
 				attribute.String("path", r.URL.Path),
@@ -165,10 +203,7 @@ func GinMiddleware(client Client, config MiddlewareConfig) gin.HandlerFunc {
 	metrics := client.NewMetricsHelper(config.ServiceName + "/http")
 	logs := client.NewLogsHelper(config.ServiceName + "/http")
 
-	requestCounter, _ := metrics.NewCounter("http_requests_total", "Total de requisições HTTP")
-	requestDuration, _ := metrics.NewHistogram("http_request_duration_seconds", "Duração das requisições HTTP")
-	requestSize, _ := metrics.NewHistogram("http_request_size_bytes", "Tamanho das requisições HTTP")
-	responseSize, _ := metrics.NewHistogram("http_response_size_bytes", "Tamanho das respostas HTTP")
+	requestCounter, requestDuration, requestSize, responseSize := newHTTPMetrics(metrics)
 
 	return func(c *gin.Context) {
 		if shouldSkip(c.Request.URL.Path, config.SkipPaths) {
@@ -248,6 +283,8 @@ func GinMiddleware(client Client, config MiddlewareConfig) gin.HandlerFunc {
 			attribute.Int("status", statusCode),
 		)
 
+		config.SLOs.ObserveRequest(ctx, c.Request.Method, c.FullPath(), statusCode, duration)
+
 		ctxLogger.Info("Requisição finalizada",
 			attribute.String("method", c.Request.Method),
 			attribute.String("path", c.FullPath()),
@@ -264,10 +301,7 @@ func EchoMiddleware(client Client, config MiddlewareConfig) echo.MiddlewareFunc
 	metrics := client.NewMetricsHelper(config.ServiceName + "/http")
 	logs := client.NewLogsHelper(config.ServiceName + "/http")
 
-	requestCounter, _ := metrics.NewCounter("http_requests_total", "Total de requisições HTTP")
-	requestDuration, _ := metrics.NewHistogram("http_request_duration_seconds", "Duração das requisições HTTP")
-	requestSize, _ := metrics.NewHistogram("http_request_size_bytes", "Tamanho das requisições HTTP")
-	responseSize, _ := metrics.NewHistogram("http_response_size_bytes", "Tamanho das respostas HTTP")
+	requestCounter, requestDuration, requestSize, responseSize := newHTTPMetrics(metrics)
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -351,6 +385,8 @@ func EchoMiddleware(client Client, config MiddlewareConfig) echo.MiddlewareFunc
 				attribute.Int("status", statusCode),
 			)
 
+			config.SLOs.ObserveRequest(ctx, c.Request().Method, c.Path(), statusCode, duration)
+
 			ctxLogger.Info("Requisição finalizada",
 				attribute.String("method", c.Request().Method),
 				attribute.String("path", c.Path()),
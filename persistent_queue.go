@@ -0,0 +1,893 @@
+package graftel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// queueRecordMagic identifica o início de cada registro gravado em um segmento ("GRFT").
+	queueRecordMagic uint32 = 0x47524654
+
+	// queueRecordHeaderSize é o tamanho do cabeçalho fixo de cada registro: magic, tamanho e CRC32.
+	queueRecordHeaderSize = 12
+
+	// queueSegmentSuffix é a extensão dos arquivos de segmento.
+	queueSegmentSuffix = ".seg"
+
+	// queueCursorFile guarda a posição de leitura do segmento mais antigo.
+	queueCursorFile = "cursor"
+
+	// defaultQueueRetryInterval é o intervalo entre tentativas de drenar a fila.
+	defaultQueueRetryInterval = 5 * time.Second
+
+	// maxQueueRetryInterval limita o backoff exponencial entre tentativas que falharam.
+	maxQueueRetryInterval = time.Minute
+)
+
+var (
+	// errQueueCorrupted indica um registro truncado ou com checksum inválido.
+	errQueueCorrupted = errors.New("registro corrompido na fila persistente")
+
+	// errQueueRecordTooLarge indica um registro maior que o limite total da fila.
+	errQueueRecordTooLarge = errors.New("registro maior que o limite da fila persistente")
+)
+
+// queueRecord é uma requisição OTLP já serializada, pronta para ser reenviada.
+type queueRecord struct {
+	Timestamp time.Time
+	Method    string
+	URL       string
+	Header    http.Header
+	Body      []byte
+}
+
+// queuePosition identifica um registro lido por peek, para que pop só avance
+// se o segmento não tiver sido descartado nesse meio tempo.
+type queuePosition struct {
+	seq    uint64
+	offset int64
+	size   int64
+}
+
+// queueSegment descreve um arquivo de segmento em disco.
+type queueSegment struct {
+	seq     uint64
+	path    string
+	size    int64
+	records int64
+}
+
+// persistentQueue é uma fila write-ahead em disco para um único sinal (logs, metrics ou traces).
+// Ela é usada como http.RoundTripper pelos exporters OTLP: quando o endpoint falha, a requisição
+// é gravada em disco e confirmada ao exporter; um goroutine drena a fila em ordem quando o
+// endpoint volta a responder.
+type persistentQueue struct {
+	signal        string
+	dir           string
+	maxBytes      int64
+	segmentBytes  int64
+	headers       map[string]string
+	base          http.RoundTripper
+	timeout       time.Duration
+	retryInterval time.Duration
+
+	mu         sync.Mutex
+	segments   []*queueSegment
+	active     *os.File
+	nextSeq    uint64
+	readOffset int64
+	records    int64
+	dropped    int64
+	corrupted  int64
+	oldest     time.Time
+	// retrying indica que a última drenagem falhou: novos registros não acordam o
+	// goroutine de drenagem, que espera o backoff.
+	retrying bool
+
+	wake      chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// newPersistentQueue abre (ou cria) a fila em dir e inicia o goroutine de drenagem.
+// Os headers informados são aplicados no reenvio e nunca são gravados em disco,
+// evitando persistir credenciais.
+func newPersistentQueue(signal, dir string, maxBytes int64, headers map[string]string, base http.RoundTripper, timeout time.Duration) (*persistentQueue, error) {
+	q, err := openPersistentQueue(signal, dir, maxBytes, headers, base, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	q.wg.Add(1)
+	go q.drainLoop()
+
+	return q, nil
+}
+
+// openPersistentQueue abre a fila sem iniciar a drenagem em segundo plano.
+func openPersistentQueue(signal, dir string, maxBytes int64, headers map[string]string, base http.RoundTripper, timeout time.Duration) (*persistentQueue, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("falha ao criar diretório da fila: %w", err)
+	}
+
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	segmentBytes := maxBytes / 8
+	if segmentBytes < 64*1024 {
+		segmentBytes = 64 * 1024
+	}
+
+	q := &persistentQueue{
+		signal:        signal,
+		dir:           dir,
+		maxBytes:      maxBytes,
+		segmentBytes:  segmentBytes,
+		headers:       headers,
+		base:          base,
+		timeout:       timeout,
+		retryInterval: defaultQueueRetryInterval,
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// load recupera os segmentos existentes e a posição de leitura após um restart.
+// Segmentos existentes nunca recebem novos registros; a escrita sempre começa em um segmento novo.
+func (q *persistentQueue) load() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return fmt.Errorf("falha ao ler diretório da fila: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, queueSegmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, queueSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		q.segments = append(q.segments, &queueSegment{
+			seq:  seq,
+			path: filepath.Join(q.dir, name),
+			size: info.Size(),
+		})
+	}
+
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i].seq < q.segments[j].seq })
+
+	// Restaurar cursor e descartar segmentos já drenados
+	if seq, offset, ok := q.readCursor(); ok {
+		for len(q.segments) > 0 && q.segments[0].seq < seq {
+			_ = os.Remove(q.segments[0].path)
+			q.segments = q.segments[1:]
+		}
+		if len(q.segments) > 0 && q.segments[0].seq == seq && offset <= q.segments[0].size {
+			q.readOffset = offset
+		}
+	}
+
+	// Contar registros válidos em cada segmento
+	for i, seg := range q.segments {
+		start := int64(0)
+		if i == 0 {
+			start = q.readOffset
+		}
+		seg.records = countSegmentRecords(seg.path, start, q.maxBytes)
+		q.records += seg.records
+	}
+
+	if len(q.segments) > 0 {
+		q.nextSeq = q.segments[len(q.segments)-1].seq + 1
+	} else {
+		q.nextSeq = 1
+	}
+
+	q.refreshOldest()
+
+	return nil
+}
+
+// RoundTrip implementa http.RoundTripper. Se houver backlog, a requisição entra no fim da fila
+// para preservar a ordem; caso contrário é enviada e só é persistida se o endpoint falhar.
+func (q *persistentQueue) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rec := queueRecord{
+		Timestamp: time.Now(),
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Header:    req.Header.Clone(),
+		Body:      body,
+	}
+
+	if q.Len() > 0 {
+		if err := q.push(rec); err != nil {
+			return nil, err
+		}
+		return spooledResponse(req), nil
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := q.base.RoundTrip(out)
+	if err == nil && !isRetryableStatus(resp.StatusCode) {
+		return resp, nil
+	}
+
+	// Falha recuperável: preservar a resposta original caso a fila não aceite o registro
+	var respBody []byte
+	if resp != nil {
+		respBody, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+
+	if perr := q.push(rec); perr != nil {
+		otel.Handle(fmt.Errorf("graftel: fila persistente de %s: %w", q.signal, perr))
+		return resp, err
+	}
+
+	return spooledResponse(req), nil
+}
+
+// Len retorna o número de registros pendentes na fila.
+func (q *persistentQueue) Len() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.records
+}
+
+// push grava um registro no segmento ativo, aplicando o limite de tamanho.
+func (q *persistentQueue) push(rec queueRecord) error {
+	data := encodeQueueRecord(rec)
+	size := int64(len(data))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if size > q.maxBytes {
+		q.dropped++
+		return errQueueRecordTooLarge
+	}
+
+	// Descartar os segmentos mais antigos até caber no limite
+	for q.pendingBytesLocked()+size > q.maxBytes && len(q.segments) > 0 {
+		q.dropOldestSegmentLocked()
+	}
+
+	if q.active == nil || q.segments[len(q.segments)-1].size >= q.segmentBytes {
+		if err := q.rotateLocked(); err != nil {
+			q.dropped++
+			return err
+		}
+	}
+
+	if _, err := q.active.Write(data); err != nil {
+		q.dropped++
+		return fmt.Errorf("falha ao gravar na fila: %w", err)
+	}
+
+	seg := q.segments[len(q.segments)-1]
+	seg.size += size
+	seg.records++
+	q.records++
+	if q.oldest.IsZero() {
+		q.oldest = rec.Timestamp
+	}
+
+	// Durante o backoff, a próxima tentativa fica a cargo do timer de drainLoop
+	if !q.retrying {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// rotateLocked fecha o segmento ativo e abre um novo.
+func (q *persistentQueue) rotateLocked() error {
+	if q.active != nil {
+		_ = q.active.Close()
+		q.active = nil
+	}
+
+	seq := q.nextSeq
+	path := filepath.Join(q.dir, fmt.Sprintf("%020d%s", seq, queueSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("falha ao criar segmento da fila: %w", err)
+	}
+
+	q.nextSeq++
+	q.active = f
+	q.segments = append(q.segments, &queueSegment{seq: seq, path: path})
+	return nil
+}
+
+// isActiveLocked indica se o segmento é o que está recebendo escritas.
+func (q *persistentQueue) isActiveLocked(seg *queueSegment) bool {
+	return q.active != nil && seg == q.segments[len(q.segments)-1]
+}
+
+// dropOldestSegmentLocked remove o segmento mais antigo, contabilizando os registros perdidos.
+func (q *persistentQueue) dropOldestSegmentLocked() {
+	seg := q.segments[0]
+	if q.isActiveLocked(seg) {
+		_ = q.active.Close()
+		q.active = nil
+	}
+	_ = os.Remove(seg.path)
+
+	q.segments = q.segments[1:]
+	q.records -= seg.records
+	q.dropped += seg.records
+	q.readOffset = 0
+	q.writeCursorLocked()
+	q.refreshOldestLocked()
+}
+
+// pendingBytesLocked retorna o total de bytes ainda não drenados.
+func (q *persistentQueue) pendingBytesLocked() int64 {
+	var total int64
+	for _, seg := range q.segments {
+		total += seg.size
+	}
+	return total - q.readOffset
+}
+
+// peek lê o próximo registro a ser drenado sem removê-lo.
+// Registros corrompidos fazem o restante do segmento ser descartado.
+func (q *persistentQueue) peek() (queueRecord, queuePosition, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.segments) > 0 {
+		seg := q.segments[0]
+		if q.readOffset >= seg.size {
+			if q.isActiveLocked(seg) {
+				return queueRecord{}, queuePosition{}, false
+			}
+			q.removeHeadLocked()
+			continue
+		}
+
+		rec, n, err := readQueueRecordAt(seg.path, q.readOffset, q.maxBytes)
+		if err != nil {
+			if q.isActiveLocked(seg) {
+				// Não deveria acontecer: escritas e leituras são serializadas pelo mutex
+				return queueRecord{}, queuePosition{}, false
+			}
+			q.corrupted++
+			otel.Handle(fmt.Errorf("graftel: fila persistente de %s: %w em %s, descartando restante do segmento", q.signal, err, seg.path))
+			q.records -= seg.records
+			q.removeHeadLocked()
+			continue
+		}
+		return rec, queuePosition{seq: seg.seq, offset: q.readOffset, size: n}, true
+	}
+
+	return queueRecord{}, queuePosition{}, false
+}
+
+// pop avança o cursor após o registro em pos ter sido drenado.
+func (q *persistentQueue) pop(pos queuePosition) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.segments) == 0 || q.segments[0].seq != pos.seq || q.readOffset != pos.offset {
+		// O segmento foi descartado pelo limite de tamanho durante o envio
+		return
+	}
+
+	seg := q.segments[0]
+	q.readOffset += pos.size
+	seg.records--
+	q.records--
+
+	if q.readOffset >= seg.size {
+		if q.isActiveLocked(seg) {
+			// Segmento ativo totalmente drenado: liberar o arquivo
+			_ = q.active.Close()
+			q.active = nil
+		}
+		q.removeHeadLocked()
+		return
+	}
+
+	q.writeCursorLocked()
+	q.refreshOldestLocked()
+}
+
+// removeHeadLocked apaga o segmento mais antigo após ele ter sido drenado ou descartado.
+func (q *persistentQueue) removeHeadLocked() {
+	_ = os.Remove(q.segments[0].path)
+	q.segments = q.segments[1:]
+	q.readOffset = 0
+	q.writeCursorLocked()
+	q.refreshOldestLocked()
+}
+
+// refreshOldest atualiza o timestamp do registro mais antigo pendente.
+func (q *persistentQueue) refreshOldest() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.refreshOldestLocked()
+}
+
+func (q *persistentQueue) refreshOldestLocked() {
+	q.oldest = time.Time{}
+	if len(q.segments) == 0 {
+		return
+	}
+	if rec, _, err := readQueueRecordAt(q.segments[0].path, q.readOffset, q.maxBytes); err == nil {
+		q.oldest = rec.Timestamp
+	}
+}
+
+// readCursor lê a posição de leitura persistida.
+func (q *persistentQueue) readCursor() (seq uint64, offset int64, ok bool) {
+	data, err := os.ReadFile(filepath.Join(q.dir, queueCursorFile))
+	if err != nil {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscanf(string(data), "%d %d", &seq, &offset); err != nil {
+		return 0, 0, false
+	}
+	return seq, offset, true
+}
+
+// writeCursorLocked persiste a posição de leitura para evitar reenvios após restart.
+func (q *persistentQueue) writeCursorLocked() {
+	path := filepath.Join(q.dir, queueCursorFile)
+	if len(q.segments) == 0 {
+		_ = os.Remove(path)
+		return
+	}
+	data := fmt.Sprintf("%d %d", q.segments[0].seq, q.readOffset)
+	_ = os.WriteFile(path, []byte(data), 0o644)
+}
+
+// drainLoop reenvia os registros pendentes em ordem até a fila esvaziar ou o endpoint falhar.
+// Após uma falha, as tentativas seguem um backoff exponencial a partir de retryInterval,
+// limitado a maxQueueRetryInterval, e novos registros não antecipam a próxima tentativa.
+func (q *persistentQueue) drainLoop() {
+	defer q.wg.Done()
+
+	delay := q.retryInterval
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		case <-timer.C:
+		}
+
+		ok := q.drain()
+		if ok {
+			delay = q.retryInterval
+		} else {
+			delay = min(delay*2, maxQueueRetryInterval)
+		}
+
+		q.mu.Lock()
+		q.retrying = !ok
+		q.mu.Unlock()
+		if !ok {
+			// Descartar um wake enviado antes de retrying ser marcado
+			select {
+			case <-q.wake:
+			default:
+			}
+		}
+		timer.Reset(delay)
+	}
+}
+
+// drain envia registros até a fila esvaziar ou encontrar uma falha recuperável.
+// Retorna false se o envio falhou.
+func (q *persistentQueue) drain() bool {
+	for {
+		select {
+		case <-q.done:
+			return true
+		default:
+		}
+
+		rec, pos, ok := q.peek()
+		if !ok {
+			return true
+		}
+
+		if err := q.send(rec); err != nil {
+			return false
+		}
+		q.pop(pos)
+	}
+}
+
+// send reenvia um registro. Retorna erro apenas para falhas recuperáveis; registros rejeitados
+// definitivamente pelo endpoint são descartados.
+func (q *persistentQueue) send(rec queueRecord) error {
+	ctx := context.Background()
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, rec.Method, rec.URL, bytes.NewReader(rec.Body))
+	if err != nil {
+		otel.Handle(fmt.Errorf("graftel: fila persistente de %s: registro inválido descartado: %w", q.signal, err))
+		q.countDropped()
+		return nil
+	}
+	req.Header = rec.Header.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	for k, v := range q.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := q.base.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if isRetryableStatus(resp.StatusCode) {
+		return fmt.Errorf("status HTTP %d", resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		otel.Handle(fmt.Errorf("graftel: fila persistente de %s: registro rejeitado com status HTTP %d", q.signal, resp.StatusCode))
+		q.countDropped()
+	}
+	return nil
+}
+
+func (q *persistentQueue) countDropped() {
+	q.mu.Lock()
+	q.dropped++
+	q.mu.Unlock()
+}
+
+// queueStats é um retrato do estado da fila usado pelas métricas.
+type queueStats struct {
+	Bytes     int64
+	Records   int64
+	OldestAge time.Duration
+	Dropped   int64
+	Corrupted int64
+}
+
+// Stats retorna o estado atual da fila.
+func (q *persistentQueue) Stats() queueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := queueStats{
+		Bytes:     q.pendingBytesLocked(),
+		Records:   q.records,
+		Dropped:   q.dropped,
+		Corrupted: q.corrupted,
+	}
+	if !q.oldest.IsZero() && q.records > 0 {
+		stats.OldestAge = time.Since(q.oldest)
+	}
+	return stats
+}
+
+// Close interrompe a drenagem e fecha o segmento ativo. Os registros pendentes
+// permanecem em disco e serão drenados na próxima execução.
+func (q *persistentQueue) Close() error {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+	q.wg.Wait()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.active != nil {
+		err := q.active.Close()
+		q.active = nil
+		return err
+	}
+	return nil
+}
+
+// registerQueueMetrics registra métricas de backlog das filas persistentes.
+func registerQueueMetrics(meter otelmetric.Meter, queues []*persistentQueue) (otelmetric.Registration, error) {
+	size, err := meter.Int64ObservableGauge("graftel.export_queue.size",
+		otelmetric.WithDescription("Bytes pendentes na fila persistente de exportação"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := meter.Int64ObservableGauge("graftel.export_queue.records",
+		otelmetric.WithDescription("Registros pendentes na fila persistente de exportação"),
+		otelmetric.WithUnit("{record}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	age, err := meter.Float64ObservableGauge("graftel.export_queue.oldest_age",
+		otelmetric.WithDescription("Idade do registro mais antigo na fila persistente de exportação"),
+		otelmetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped, err := meter.Int64ObservableCounter("graftel.export_queue.dropped",
+		otelmetric.WithDescription("Registros descartados pela fila persistente de exportação"),
+		otelmetric.WithUnit("{record}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		for _, q := range queues {
+			stats := q.Stats()
+			attrs := otelmetric.WithAttributes(attribute.String("signal", q.signal))
+			o.ObserveInt64(size, stats.Bytes, attrs)
+			o.ObserveInt64(records, stats.Records, attrs)
+			o.ObserveFloat64(age, stats.OldestAge.Seconds(), attrs)
+			o.ObserveInt64(dropped, stats.Dropped, attrs)
+		}
+		return nil
+	}, size, records, age, dropped)
+}
+
+// isRetryableStatus indica se o status HTTP representa uma indisponibilidade temporária.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// spooledResponse é a resposta sintética devolvida ao exporter quando a requisição foi persistida.
+func spooledResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
+
+// encodeQueueRecord serializa um registro no formato:
+// magic(4) | tamanho(4) | crc32(4) | payload
+// onde payload = timestamp(8) | method | url | headers | body, com strings prefixadas por uvarint.
+// Os headers são gravados como a quantidade de pares seguida de um par chave/valor por valor.
+func encodeQueueRecord(rec queueRecord) []byte {
+	payload := make([]byte, 0, 64+len(rec.URL)+len(rec.Body))
+	payload = binary.BigEndian.AppendUint64(payload, uint64(rec.Timestamp.UnixNano()))
+	payload = appendQueueString(payload, rec.Method)
+	payload = appendQueueString(payload, rec.URL)
+
+	// Headers sensíveis nunca são persistidos; são reaplicados no reenvio
+	keys := make([]string, 0, len(rec.Header))
+	for k := range rec.Header {
+		if strings.EqualFold(k, "Authorization") {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// Cada valor vira um par chave/valor, preservando headers com vários valores
+	var pairs int
+	for _, k := range keys {
+		pairs += len(rec.Header[k])
+	}
+	payload = binary.AppendUvarint(payload, uint64(pairs))
+	for _, k := range keys {
+		for _, v := range rec.Header[k] {
+			payload = appendQueueString(payload, k)
+			payload = appendQueueString(payload, v)
+		}
+	}
+	payload = append(payload, rec.Body...)
+
+	data := make([]byte, queueRecordHeaderSize, queueRecordHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(data[0:4], queueRecordMagic)
+	binary.BigEndian.PutUint32(data[4:8], uint32(len(payload)))
+	binary.BigEndian.PutUint32(data[8:12], crc32.ChecksumIEEE(payload))
+	return append(data, payload...)
+}
+
+func appendQueueString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// readQueueRecord lê o próximo registro de r, que tem no máximo limit bytes restantes.
+// Retorna io.EOF no fim limpo do segmento e errQueueCorrupted para registros truncados
+// ou inválidos, incluindo tamanhos maiores que limit (evita alocar a partir de um
+// cabeçalho corrompido).
+func readQueueRecord(r io.Reader, limit int64) (queueRecord, int64, error) {
+	var header [queueRecordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return queueRecord{}, 0, io.EOF
+		}
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+
+	if binary.BigEndian.Uint32(header[0:4]) != queueRecordMagic {
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+
+	size := int64(binary.BigEndian.Uint32(header[4:8]))
+	if size > limit-queueRecordHeaderSize {
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[8:12]) {
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+
+	rec, err := decodeQueuePayload(payload)
+	if err != nil {
+		return queueRecord{}, 0, err
+	}
+	return rec, int64(queueRecordHeaderSize + len(payload)), nil
+}
+
+func decodeQueuePayload(payload []byte) (queueRecord, error) {
+	var rec queueRecord
+	if len(payload) < 8 {
+		return rec, errQueueCorrupted
+	}
+	rec.Timestamp = time.Unix(0, int64(binary.BigEndian.Uint64(payload[:8])))
+	rest := payload[8:]
+
+	var ok bool
+	if rec.Method, rest, ok = readQueueString(rest); !ok {
+		return rec, errQueueCorrupted
+	}
+	if rec.URL, rest, ok = readQueueString(rest); !ok {
+		return rec, errQueueCorrupted
+	}
+
+	count, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return rec, errQueueCorrupted
+	}
+	rest = rest[n:]
+	rec.Header = make(http.Header, count)
+	for i := uint64(0); i < count; i++ {
+		var k, v string
+		if k, rest, ok = readQueueString(rest); !ok {
+			return rec, errQueueCorrupted
+		}
+		if v, rest, ok = readQueueString(rest); !ok {
+			return rec, errQueueCorrupted
+		}
+		rec.Header.Add(k, v)
+	}
+
+	rec.Body = rest
+	return rec, nil
+}
+
+func readQueueString(b []byte) (string, []byte, bool) {
+	l, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < l {
+		return "", nil, false
+	}
+	return string(b[n : n+int(l)]), b[n+int(l):], true
+}
+
+// readQueueRecordAt lê um registro de um segmento a partir de offset. O registro é
+// limitado ao restante do segmento e a maxBytes.
+func readQueueRecordAt(path string, offset, maxBytes int64) (queueRecord, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return queueRecord{}, 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return queueRecord{}, 0, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return queueRecord{}, 0, err
+	}
+
+	rec, n, err := readQueueRecord(bufio.NewReader(f), min(info.Size()-offset, maxBytes))
+	if err == io.EOF {
+		return queueRecord{}, 0, errQueueCorrupted
+	}
+	return rec, n, err
+}
+
+// countSegmentRecords conta os registros válidos de um segmento a partir de offset,
+// parando no primeiro registro corrompido.
+func countSegmentRecords(path string, offset, maxBytes int64) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0
+	}
+
+	r := bufio.NewReader(f)
+	remaining := min(info.Size()-offset, maxBytes)
+	var count int64
+	for {
+		_, n, err := readQueueRecord(r, remaining)
+		if err != nil {
+			return count
+		}
+		remaining -= n
+		count++
+	}
+}
@@ -0,0 +1,429 @@
+package graftel
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// flakyServer simula um coletor que pode ser derrubado e restaurado.
+type flakyServer struct {
+	mu     sync.Mutex
+	up     atomic.Bool
+	bodies []string
+	auth   []string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.up.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	s.bodies = append(s.bodies, string(body))
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *flakyServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.bodies...)
+}
+
+func newTestQueue(t *testing.T, dir string, maxBytes int64) *persistentQueue {
+	t.Helper()
+	// Sem drenagem em segundo plano: os testes chamam drain explicitamente
+	q, err := openPersistentQueue("logs", dir, maxBytes, map[string]string{"Authorization": "Basic test"}, nil, time.Second)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() erro = %v", err)
+	}
+	return q
+}
+
+func postThrough(t *testing.T, q *persistentQueue, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Authorization", "Basic secret")
+	resp, err := q.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() erro = %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp
+}
+
+func TestQueueRecord_EncodeDecode(t *testing.T) {
+	rec := queueRecord{
+		Timestamp: time.Unix(0, 1234567890),
+		Method:    http.MethodPost,
+		URL:       "http://localhost:4318/v1/logs",
+		Header: http.Header{
+			"Content-Type":  {"application/x-protobuf"},
+			"Authorization": {"Basic secret"},
+			"X-Tenant":      {"a", "b"},
+		},
+		Body: []byte("payload"),
+	}
+
+	data := encodeQueueRecord(rec)
+	got, n, err := readQueueRecord(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("readQueueRecord() erro = %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Errorf("tamanho lido = %d, esperado %d", n, len(data))
+	}
+	if !got.Timestamp.Equal(rec.Timestamp) || got.URL != rec.URL || string(got.Body) != "payload" {
+		t.Errorf("registro decodificado incorreto: %+v", got)
+	}
+	if got.Header.Get("Authorization") != "" {
+		t.Error("Authorization não deve ser persistido")
+	}
+	if got.Header.Get("Content-Type") != "application/x-protobuf" {
+		t.Error("Content-Type deve ser persistido")
+	}
+	if values := got.Header.Values("X-Tenant"); len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Errorf("X-Tenant = %v, esperado todos os valores [a b]", values)
+	}
+
+	// Tamanho maior que o restante do segmento é tratado como corrupção, sem alocar
+	if _, _, err := readQueueRecord(bytes.NewReader(data), int64(len(data)-1)); err != errQueueCorrupted {
+		t.Errorf("esperado errQueueCorrupted para tamanho acima do limite, obtido %v", err)
+	}
+	huge := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(huge[4:8], math.MaxUint32)
+	if _, _, err := readQueueRecord(bytes.NewReader(huge), 1<<20); err != errQueueCorrupted {
+		t.Errorf("esperado errQueueCorrupted para cabeçalho com 4 GiB, obtido %v", err)
+	}
+
+	// Corromper o payload
+	data[len(data)-1] ^= 0xff
+	if _, _, err := readQueueRecord(bytes.NewReader(data), int64(len(data))); err != errQueueCorrupted {
+		t.Errorf("esperado errQueueCorrupted, obtido %v", err)
+	}
+}
+
+func TestPersistentQueue_SpoolsAndDrainsInOrder(t *testing.T) {
+	server := &flakyServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	q := newTestQueue(t, t.TempDir(), 1<<20)
+	defer q.Close()
+
+	for _, body := range []string{"a", "b", "c"} {
+		resp := postThrough(t, q, ts.URL, body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("esperado status sintético 200, obtido %d", resp.StatusCode)
+		}
+	}
+
+	if got := q.Len(); got != 3 {
+		t.Fatalf("esperado 3 registros na fila, obtido %d", got)
+	}
+
+	// Com backlog, novos envios vão para o fim da fila mesmo com o coletor de volta
+	server.up.Store(true)
+	postThrough(t, q, ts.URL, "d")
+	if len(server.received()) != 0 {
+		t.Fatal("envio direto não deve furar a fila")
+	}
+
+	q.drain()
+
+	got := server.received()
+	want := []string{"a", "b", "c", "d"}
+	if len(got) != len(want) {
+		t.Fatalf("esperado %v, obtido %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ordem incorreta: esperado %v, obtido %v", want, got)
+		}
+	}
+	for _, auth := range server.auth {
+		if auth != "Basic test" {
+			t.Errorf("header de autenticação não reaplicado: %q", auth)
+		}
+	}
+
+	stats := q.Stats()
+	if stats.Records != 0 || stats.Bytes != 0 {
+		t.Errorf("fila deveria estar vazia: %+v", stats)
+	}
+
+	// Sem backlog, o envio é direto
+	postThrough(t, q, ts.URL, "e")
+	if got := server.received(); got[len(got)-1] != "e" {
+		t.Errorf("esperado envio direto, obtido %v", got)
+	}
+}
+
+func TestPersistentQueue_BackoffBoundsRetries(t *testing.T) {
+	var attempts atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	q := newTestQueue(t, t.TempDir(), 1<<20)
+	q.retryInterval = 20 * time.Millisecond
+	q.wg.Add(1)
+	go q.drainLoop()
+	defer q.Close()
+
+	// Com o coletor fora, cada envio é persistido; os envios não devem disparar
+	// uma nova tentativa cada, apenas o backoff (20ms, 40ms, 80ms, ...)
+	deadline := time.Now().Add(200 * time.Millisecond)
+	var posts int
+	for time.Now().Before(deadline) {
+		postThrough(t, q, ts.URL, "x")
+		posts++
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// 1 envio direto + no máximo ~4 tentativas de drenagem em 200ms, com folga
+	if got := attempts.Load(); got > 8 {
+		t.Errorf("%d tentativas para %d envios: o backoff deveria limitar as tentativas", got, posts)
+	}
+	if got := q.Len(); got != int64(posts) {
+		t.Errorf("esperado %d registros na fila, obtido %d", posts, got)
+	}
+}
+
+func TestPersistentQueue_SurvivesRestart(t *testing.T) {
+	server := &flakyServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	dir := t.TempDir()
+	q := newTestQueue(t, dir, 1<<20)
+	postThrough(t, q, ts.URL, "a")
+	postThrough(t, q, ts.URL, "b")
+	server.up.Store(true)
+
+	// Drenar apenas o primeiro registro antes do "restart"
+	rec, pos, ok := q.peek()
+	if !ok || string(rec.Body) != "a" {
+		t.Fatalf("peek() = %q, %v", rec.Body, ok)
+	}
+	if err := q.send(rec); err != nil {
+		t.Fatal(err)
+	}
+	q.pop(pos)
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := newTestQueue(t, dir, 1<<20)
+	defer reopened.Close()
+
+	if got := reopened.Len(); got != 1 {
+		t.Fatalf("esperado 1 registro após restart, obtido %d", got)
+	}
+	if reopened.Stats().OldestAge <= 0 {
+		t.Error("idade do registro mais antigo deveria ser positiva")
+	}
+
+	reopened.drain()
+	got := server.received()
+	if len(got) != 2 || got[1] != "b" {
+		t.Fatalf("esperado [a b], obtido %v", got)
+	}
+}
+
+func TestPersistentQueue_SkipsCorruptedSegment(t *testing.T) {
+	server := &flakyServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	dir := t.TempDir()
+	q := newTestQueue(t, dir, 1<<20)
+	postThrough(t, q, ts.URL, "a")
+	_ = q.Close()
+
+	// Segmento truncado no meio de um registro, como após uma queda de energia
+	corrupted := filepath.Join(dir, "00000000000000000000.seg")
+	full := encodeQueueRecord(queueRecord{Method: http.MethodPost, URL: ts.URL, Body: []byte("perdido")})
+	if err := os.WriteFile(corrupted, full[:len(full)-3], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := newTestQueue(t, dir, 1<<20)
+	defer reopened.Close()
+	postThrough(t, reopened, ts.URL, "b")
+
+	server.up.Store(true)
+	reopened.drain()
+
+	got := server.received()
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("esperado [a b], obtido %v", got)
+	}
+	if reopened.Stats().Corrupted != 1 {
+		t.Errorf("esperado 1 segmento corrompido, obtido %d", reopened.Stats().Corrupted)
+	}
+}
+
+func TestPersistentQueue_SkipsSegmentWithOversizedRecord(t *testing.T) {
+	server := &flakyServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	dir := t.TempDir()
+	q := newTestQueue(t, dir, 1<<20)
+	postThrough(t, q, ts.URL, "a")
+	_ = q.Close()
+
+	// Cabeçalho com tamanho de 4 GiB: deve ser descartado sem alocar o payload
+	corrupted := filepath.Join(dir, "00000000000000000000.seg")
+	full := encodeQueueRecord(queueRecord{Method: http.MethodPost, URL: ts.URL, Body: []byte("perdido")})
+	binary.BigEndian.PutUint32(full[4:8], math.MaxUint32)
+	if err := os.WriteFile(corrupted, full, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := newTestQueue(t, dir, 1<<20)
+	defer reopened.Close()
+
+	server.up.Store(true)
+	reopened.drain()
+
+	if got := server.received(); len(got) != 1 || got[0] != "a" {
+		t.Fatalf("esperado [a], obtido %v", got)
+	}
+	if reopened.Stats().Corrupted != 1 {
+		t.Errorf("esperado 1 segmento corrompido, obtido %d", reopened.Stats().Corrupted)
+	}
+}
+
+func TestPersistentQueue_MaxBytesDropsOldest(t *testing.T) {
+	server := &flakyServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	q := newTestQueue(t, t.TempDir(), 200*1024)
+	defer q.Close()
+
+	body := string(bytes.Repeat([]byte("x"), 30*1024))
+	for i := 0; i < 10; i++ {
+		postThrough(t, q, ts.URL, body)
+	}
+
+	stats := q.Stats()
+	if stats.Bytes > 200*1024 {
+		t.Errorf("fila excedeu o limite: %d bytes", stats.Bytes)
+	}
+	if stats.Dropped == 0 {
+		t.Error("esperado descarte de registros antigos")
+	}
+	if stats.Records+stats.Dropped != 10 {
+		t.Errorf("registros inconsistentes: %+v", stats)
+	}
+}
+
+func TestPersistentQueue_NonRetryableStatusPassesThrough(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	q := newTestQueue(t, t.TempDir(), 1<<20)
+	defer q.Close()
+
+	resp := postThrough(t, q, ts.URL, "a")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("esperado status 400 repassado, obtido %d", resp.StatusCode)
+	}
+	if q.Len() != 0 {
+		t.Error("requisições rejeitadas não devem ser enfileiradas")
+	}
+}
+
+func TestRegisterQueueMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	q := newTestQueue(t, t.TempDir(), 1<<20)
+	defer q.Close()
+	postThrough(t, q, "http://127.0.0.1:1", "a")
+
+	reg, err := registerQueueMetrics(provider.Meter("test"), []*persistentQueue{q})
+	if err != nil {
+		t.Fatalf("registerQueueMetrics() erro = %v", err)
+	}
+	defer reg.Unregister()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if m.Name == "graftel.export_queue.records" {
+				gauge := m.Data.(metricdata.Gauge[int64])
+				if gauge.DataPoints[0].Value != 1 {
+					t.Errorf("esperado 1 registro, obtido %d", gauge.DataPoints[0].Value)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"graftel.export_queue.size", "graftel.export_queue.records", "graftel.export_queue.oldest_age", "graftel.export_queue.dropped"} {
+		if !found[name] {
+			t.Errorf("métrica %s não encontrada", name)
+		}
+	}
+}
+
+func TestClient_PersistentQueue(t *testing.T) {
+	dir := t.TempDir()
+	config := NewConfig("test-service").
+		WithInsecure(true).
+		WithPersistentQueue(dir, 0)
+
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() erro = %v", err)
+	}
+	if err := c.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() erro = %v", err)
+	}
+
+	impl := c.(*client)
+	if len(impl.queues) != 3 {
+		t.Errorf("esperado filas para metrics, logs e traces, obtido %d", len(impl.queues))
+	}
+	for _, signal := range []string{"metrics", "logs", "traces"} {
+		if _, err := os.Stat(filepath.Join(dir, signal)); err != nil {
+			t.Errorf("diretório da fila de %s não criado: %v", signal, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = c.Shutdown(ctx)
+}
@@ -0,0 +1,233 @@
+package graftel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// PrometheusBridgeScope é o escopo de instrumentação das métricas lidas de um prometheus.Gatherer.
+const PrometheusBridgeScope = "github.com/CristianSsousa/graftel/v2/prometheus-bridge"
+
+// prometheusProducer lê as métricas de um prometheus.Gatherer e as converte para o
+// modelo de dados do OpenTelemetry a cada coleta.
+type prometheusProducer struct {
+	gatherer promclient.Gatherer
+	start    time.Time
+
+	// unsupported guarda as famílias de tipo não suportado já reportadas.
+	unsupported sync.Map
+}
+
+// NewPrometheusProducer cria um sdkmetric.Producer que exporta as métricas de gatherer
+// (ex: prometheus.DefaultGatherer) junto com as métricas do MeterProvider.
+//
+// Contadores viram somas monotônicas cumulativas, gauges e métricas sem tipo viram gauges,
+// histogramas clássicos viram histogramas de buckets explícitos e summaries viram summaries.
+// Histogramas nativos são exportados apenas com contagem e soma. Famílias de outros tipos
+// (ex: GAUGE_HISTOGRAM) são ignoradas e reportadas uma vez ao handler do OpenTelemetry.
+func NewPrometheusProducer(gatherer promclient.Gatherer) sdkmetric.Producer {
+	return &prometheusProducer{gatherer: gatherer, start: time.Now()}
+}
+
+// Produce implementa sdkmetric.Producer. Em caso de falha parcial do Gatherer, retorna
+// as métricas lidas junto com o erro.
+func (p *prometheusProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	families, err := p.gatherer.Gather()
+	if len(families) == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	metrics := make([]metricdata.Metrics, 0, len(families))
+	for _, family := range families {
+		if m, ok := p.convert(family, now); ok {
+			metrics = append(metrics, m)
+		}
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: PrometheusBridgeScope},
+		Metrics: metrics,
+	}}, err
+}
+
+// convert converte uma família de métricas. Retorna false para tipos não suportados.
+func (p *prometheusProducer) convert(family *dto.MetricFamily, now time.Time) (metricdata.Metrics, bool) {
+	m := metricdata.Metrics{Name: family.GetName(), Description: family.GetHelp()}
+
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		points := make([]metricdata.DataPoint[float64], 0, len(family.Metric))
+		for _, pm := range family.Metric {
+			points = append(points, metricdata.DataPoint[float64]{
+				Attributes: labelSet(pm.GetLabel()),
+				StartTime:  p.startTime(pm.GetCounter().GetCreatedTimestamp().AsTime()),
+				Time:       timestamp(pm, now),
+				Value:      pm.GetCounter().GetValue(),
+			})
+		}
+		m.Data = metricdata.Sum[float64]{
+			DataPoints:  points,
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+		}
+
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		points := make([]metricdata.DataPoint[float64], 0, len(family.Metric))
+		for _, pm := range family.Metric {
+			value := pm.GetGauge().GetValue()
+			if family.GetType() == dto.MetricType_UNTYPED {
+				value = pm.GetUntyped().GetValue()
+			}
+			points = append(points, metricdata.DataPoint[float64]{
+				Attributes: labelSet(pm.GetLabel()),
+				Time:       timestamp(pm, now),
+				Value:      value,
+			})
+		}
+		m.Data = metricdata.Gauge[float64]{DataPoints: points}
+
+	case dto.MetricType_HISTOGRAM:
+		points := make([]metricdata.HistogramDataPoint[float64], 0, len(family.Metric))
+		for _, pm := range family.Metric {
+			h := pm.GetHistogram()
+			bounds, counts := histogramBuckets(h)
+			points = append(points, metricdata.HistogramDataPoint[float64]{
+				Attributes:   labelSet(pm.GetLabel()),
+				StartTime:    p.startTime(h.GetCreatedTimestamp().AsTime()),
+				Time:         timestamp(pm, now),
+				Count:        h.GetSampleCount(),
+				Sum:          h.GetSampleSum(),
+				Bounds:       bounds,
+				BucketCounts: counts,
+			})
+		}
+		m.Data = metricdata.Histogram[float64]{
+			DataPoints:  points,
+			Temporality: metricdata.CumulativeTemporality,
+		}
+
+	case dto.MetricType_SUMMARY:
+		points := make([]metricdata.SummaryDataPoint, 0, len(family.Metric))
+		for _, pm := range family.Metric {
+			s := pm.GetSummary()
+			quantiles := make([]metricdata.QuantileValue, 0, len(s.GetQuantile()))
+			for _, q := range s.GetQuantile() {
+				quantiles = append(quantiles, metricdata.QuantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+			}
+			points = append(points, metricdata.SummaryDataPoint{
+				Attributes:     labelSet(pm.GetLabel()),
+				StartTime:      p.startTime(s.GetCreatedTimestamp().AsTime()),
+				Time:           timestamp(pm, now),
+				Count:          s.GetSampleCount(),
+				Sum:            s.GetSampleSum(),
+				QuantileValues: quantiles,
+			})
+		}
+		m.Data = metricdata.Summary{DataPoints: points}
+
+	default:
+		if _, reported := p.unsupported.LoadOrStore(family.GetName(), struct{}{}); !reported {
+			otel.Handle(fmt.Errorf("graftel: ponte Prometheus: família '%s' do tipo %s não suportada e ignorada",
+				family.GetName(), family.GetType()))
+		}
+		return m, false
+	}
+	return m, true
+}
+
+// histogramBuckets converte os buckets cumulativos do Prometheus em limites e contagens
+// por bucket. O bucket +Inf é implícito no OpenTelemetry e recebe o restante das amostras.
+func histogramBuckets(h *dto.Histogram) ([]float64, []uint64) {
+	buckets := h.GetBucket()
+	bounds := make([]float64, 0, len(buckets))
+	counts := make([]uint64, 0, len(buckets)+1)
+
+	var cumulative uint64
+	for _, b := range buckets {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			break
+		}
+		bounds = append(bounds, b.GetUpperBound())
+		counts = append(counts, b.GetCumulativeCount()-cumulative)
+		cumulative = b.GetCumulativeCount()
+	}
+	return bounds, append(counts, h.GetSampleCount()-cumulative)
+}
+
+// startTime usa o timestamp de criação da série, quando exposto, ou o início do producer.
+func (p *prometheusProducer) startTime(created time.Time) time.Time {
+	if created.Unix() <= 0 {
+		return p.start
+	}
+	return created
+}
+
+// timestamp usa o timestamp explícito da amostra, quando existe, ou o momento da coleta.
+func timestamp(pm *dto.Metric, now time.Time) time.Time {
+	if pm.TimestampMs != nil {
+		return time.UnixMilli(pm.GetTimestampMs())
+	}
+	return now
+}
+
+// labelSet converte labels do Prometheus em atributos.
+func labelSet(labels []*dto.LabelPair) attribute.Set {
+	attrs := make([]attribute.KeyValue, len(labels))
+	for i, l := range labels {
+		attrs[i] = attribute.String(l.GetName(), l.GetValue())
+	}
+	return attribute.NewSet(attrs...)
+}
+
+// WithPrometheusBridge exporta, junto com as métricas do Client, as métricas dos
+// collectors registrados nos gatherers informados (ex: prometheus.DefaultGatherer).
+func (c Config) WithPrometheusBridge(gatherers ...promclient.Gatherer) Config {
+	c.PrometheusGatherers = append(append([]promclient.Gatherer{}, c.PrometheusGatherers...), gatherers...)
+	return c
+}
+
+// prometheusProducers cria os producers da ponte com o Prometheus. Com o exporter
+// Prometheus habilitado, prometheus.DefaultGatherer já é servido pelo handler e
+// removido aqui, inclusive de dentro de prometheus.Gatherers: coletá-lo a partir do
+// próprio exporter criaria um ciclo e duplicaria as séries.
+func prometheusProducers(gatherers []promclient.Gatherer, prometheusEnabled bool) []sdkmetric.Producer {
+	producers := make([]sdkmetric.Producer, 0, len(gatherers))
+	for _, g := range gatherers {
+		if prometheusEnabled {
+			var ok bool
+			if g, ok = withoutDefaultGatherer(g); !ok {
+				continue
+			}
+		}
+		producers = append(producers, NewPrometheusProducer(g))
+	}
+	return producers
+}
+
+// withoutDefaultGatherer remove prometheus.DefaultGatherer de g, percorrendo
+// prometheus.Gatherers recursivamente. Retorna false se nada restar. Outros wrappers
+// (ex: prometheus.GathererFunc) não podem ser inspecionados e são mantidos.
+func withoutDefaultGatherer(g promclient.Gatherer) (promclient.Gatherer, bool) {
+	if gatherers, ok := g.(promclient.Gatherers); ok {
+		filtered := make(promclient.Gatherers, 0, len(gatherers))
+		for _, inner := range gatherers {
+			if inner, ok := withoutDefaultGatherer(inner); ok {
+				filtered = append(filtered, inner)
+			}
+		}
+		return filtered, len(filtered) > 0
+	}
+	return g, g != promclient.DefaultGatherer
+}
@@ -0,0 +1,159 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPrometheusProducer_ConvertsMetricFamilies(t *testing.T) {
+	registry := promclient.NewRegistry()
+	reader := metric.NewManualReader(metric.WithProducer(NewPrometheusProducer(registry)))
+	newTestMetricsHelper(t, metric.WithReader(reader))
+
+	jobs := promclient.NewCounterVec(promclient.CounterOpts{Name: "legacy_jobs_total", Help: "Jobs legados"}, []string{"queue"})
+	inFlight := promclient.NewGauge(promclient.GaugeOpts{Name: "legacy_in_flight", Help: "Em andamento"})
+	latency := promclient.NewHistogram(promclient.HistogramOpts{Name: "legacy_latency_seconds", Buckets: []float64{0.1, 1}})
+	sizes := promclient.NewSummary(promclient.SummaryOpts{Name: "legacy_size_bytes", Objectives: map[float64]float64{0.5: 0.05}})
+	registry.MustRegister(jobs, inFlight, latency, sizes)
+
+	jobs.WithLabelValues("emails").Add(3)
+	inFlight.Set(7)
+	for _, v := range []float64{0.05, 0.5, 0.7, 5} {
+		latency.Observe(v)
+	}
+	sizes.Observe(100)
+
+	metrics := collectMetrics(t, reader)
+
+	counter, ok := metrics["legacy_jobs_total"].Data.(metricdata.Sum[float64])
+	if !ok || !counter.IsMonotonic || counter.Temporality != metricdata.CumulativeTemporality {
+		t.Fatalf("legacy_jobs_total deveria ser soma monotônica cumulativa: %+v", metrics["legacy_jobs_total"])
+	}
+	if dp := counter.DataPoints[0]; dp.Value != 3 {
+		t.Errorf("legacy_jobs_total = %v, esperado 3", dp.Value)
+	} else if v, _ := dp.Attributes.Value("queue"); v.AsString() != "emails" {
+		t.Errorf("label queue = %q, esperado emails", v.AsString())
+	}
+	if metrics["legacy_jobs_total"].Description != "Jobs legados" {
+		t.Errorf("descrição = %q", metrics["legacy_jobs_total"].Description)
+	}
+
+	if gauge := metrics["legacy_in_flight"].Data.(metricdata.Gauge[float64]); gauge.DataPoints[0].Value != 7 {
+		t.Errorf("legacy_in_flight = %v, esperado 7", gauge.DataPoints[0].Value)
+	}
+
+	histogram := metrics["legacy_latency_seconds"].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if histogram.Count != 4 || !slices.Equal(histogram.Bounds, []float64{0.1, 1}) ||
+		!slices.Equal(histogram.BucketCounts, []uint64{1, 2, 1}) {
+		t.Errorf("histograma inesperado: count=%d bounds=%v buckets=%v", histogram.Count, histogram.Bounds, histogram.BucketCounts)
+	}
+
+	summary := metrics["legacy_size_bytes"].Data.(metricdata.Summary).DataPoints[0]
+	if summary.Count != 1 || summary.Sum != 100 || len(summary.QuantileValues) != 1 {
+		t.Errorf("summary inesperado: %+v", summary)
+	}
+}
+
+type failingGatherer struct {
+	families []*dto.MetricFamily
+}
+
+func (g failingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	return g.families, errors.New("collector quebrado")
+}
+
+func TestPrometheusProducer_PartialGatherError(t *testing.T) {
+	gauge := &dto.MetricFamily{
+		Name:   proto.String("partial_gauge"),
+		Type:   dto.MetricType_GAUGE.Enum(),
+		Metric: []*dto.Metric{{Gauge: &dto.Gauge{Value: proto.Float64(1)}}},
+	}
+
+	scopes, err := NewPrometheusProducer(failingGatherer{families: []*dto.MetricFamily{gauge}}).Produce(context.Background())
+	if err == nil {
+		t.Error("esperado erro do gatherer")
+	}
+	if len(scopes) != 1 || scopes[0].Scope.Name != PrometheusBridgeScope || scopes[0].Metrics[0].Name != "partial_gauge" {
+		t.Errorf("métricas parciais deveriam ser mantidas: %+v", scopes)
+	}
+}
+
+func TestPrometheusProducers_SkipsDefaultGathererWithPrometheusExporter(t *testing.T) {
+	custom := promclient.NewRegistry()
+	gatherers := []promclient.Gatherer{promclient.DefaultGatherer, custom}
+
+	if got := len(prometheusProducers(gatherers, false)); got != 2 {
+		t.Errorf("OTLP: esperados 2 producers, obtidos %d", got)
+	}
+	if got := len(prometheusProducers(gatherers, true)); got != 1 {
+		t.Errorf("Prometheus: esperado 1 producer, obtidos %d", got)
+	}
+}
+
+func TestPrometheusProducers_UnwrapsGatherers(t *testing.T) {
+	custom := promclient.NewRegistry()
+	gatherers := []promclient.Gatherer{
+		promclient.Gatherers{promclient.DefaultGatherer, promclient.Gatherers{custom, promclient.DefaultGatherer}},
+		promclient.Gatherers{promclient.DefaultGatherer},
+	}
+
+	producers := prometheusProducers(gatherers, true)
+	if len(producers) != 1 {
+		t.Fatalf("esperado 1 producer, obtidos %d", len(producers))
+	}
+	got := producers[0].(*prometheusProducer).gatherer
+	want := promclient.Gatherers{promclient.Gatherers{custom}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("gatherer = %v, esperado %v", got, want)
+	}
+}
+
+func TestPrometheusProducer_ReportsUnsupportedFamilies(t *testing.T) {
+	errs := captureErrors(t)
+	family := &dto.MetricFamily{
+		Name:   proto.String("queue_wait"),
+		Type:   dto.MetricType_GAUGE_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{{Histogram: &dto.Histogram{SampleCount: proto.Uint64(1)}}},
+	}
+	producer := NewPrometheusProducer(promclient.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{family}, nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		scopes, err := producer.Produce(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(scopes[0].Metrics) != 0 {
+			t.Errorf("GAUGE_HISTOGRAM não deveria ser convertido: %+v", scopes[0].Metrics)
+		}
+	}
+	if got := errs(); len(got) != 1 || !strings.Contains(got[0].Error(), "queue_wait") {
+		t.Errorf("esperado um único aviso para queue_wait, obtido %v", got)
+	}
+}
+
+func TestConfig_PrometheusBridge(t *testing.T) {
+	custom := promclient.NewRegistry()
+	base := NewConfig("test")
+	config := base.WithPrometheusBridge(custom)
+	if len(config.PrometheusGatherers) != 1 || len(base.PrometheusGatherers) != 0 {
+		t.Errorf("WithPrometheusBridge deve retornar uma cópia com o gatherer: %v", config.PrometheusGatherers)
+	}
+
+	t.Setenv("GRAFTEL_PROMETHEUS_BRIDGE", "true")
+	fromEnv := NewConfig("test")
+	if len(fromEnv.PrometheusGatherers) != 1 || fromEnv.PrometheusGatherers[0] != promclient.DefaultGatherer {
+		t.Errorf("GRAFTEL_PROMETHEUS_BRIDGE deveria usar DefaultGatherer: %v", fromEnv.PrometheusGatherers)
+	}
+}
@@ -0,0 +1,324 @@
+package graftel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// PushFormat define o protocolo usado para enviar as métricas no modo push.
+type PushFormat int
+
+const (
+	// PushFormatPushgateway envia as métricas para um Pushgateway via HTTP PUT. É o padrão.
+	PushFormatPushgateway PushFormat = iota
+	// PushFormatRemoteWrite envia as métricas via Prometheus remote-write (protobuf com snappy).
+	PushFormatRemoteWrite
+)
+
+// String retorna a representação em string do formato, no formato de GRAFTEL_PUSH_FORMAT.
+func (f PushFormat) String() string {
+	switch f {
+	case PushFormatPushgateway:
+		return "pushgateway"
+	case PushFormatRemoteWrite:
+		return "remote_write"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePushFormat converte "pushgateway" ou "remote_write" em PushFormat.
+func ParsePushFormat(s string) (PushFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pushgateway":
+		return PushFormatPushgateway, nil
+	case "remote_write", "remote-write":
+		return PushFormatRemoteWrite, nil
+	default:
+		return PushFormatPushgateway, fmt.Errorf("formato de push inválido: %q", s)
+	}
+}
+
+// PushConfig configura o modo push, para jobs que terminam antes de MetricExportInterval.
+// As métricas são coletadas sob demanda e um único snapshot é enviado no Shutdown.
+type PushConfig struct {
+	// Endpoint é a URL do Pushgateway (ex: http://pushgateway:9091) ou do endpoint
+	// remote-write (ex: http://prometheus:9090/api/v1/write). Vazio desabilita o modo push.
+	Endpoint string
+
+	// Format é o protocolo de envio. Padrão: PushFormatPushgateway.
+	Format PushFormat
+
+	// Job é o grouping key "job". Padrão: service.name do resource.
+	Job string
+
+	// Instance é o grouping key "instance". Padrão: service.instance.id do resource
+	// ou o hostname.
+	Instance string
+}
+
+// loadFromEnv carrega os campos vazios de GRAFTEL_PUSH_ENDPOINT, GRAFTEL_PUSH_FORMAT,
+// GRAFTEL_PUSH_JOB e GRAFTEL_PUSH_INSTANCE.
+func (p *PushConfig) loadFromEnv() {
+	if p.Endpoint == "" {
+		p.Endpoint = os.Getenv("GRAFTEL_PUSH_ENDPOINT")
+	}
+
+	if p.Format == PushFormatPushgateway {
+		if val := os.Getenv("GRAFTEL_PUSH_FORMAT"); val != "" {
+			if format, err := ParsePushFormat(val); err == nil {
+				p.Format = format
+			}
+		}
+	}
+
+	if p.Job == "" {
+		p.Job = os.Getenv("GRAFTEL_PUSH_JOB")
+	}
+
+	if p.Instance == "" {
+		p.Instance = os.Getenv("GRAFTEL_PUSH_INSTANCE")
+	}
+}
+
+// validate verifica a configuração do modo push.
+func (p PushConfig) validate() error {
+	if p.Format < PushFormatPushgateway || p.Format > PushFormatRemoteWrite {
+		return &ErrInvalidConfig{Field: "Push.Format", Message: "valor desconhecido"}
+	}
+	if !strings.HasPrefix(p.Endpoint, "http://") && !strings.HasPrefix(p.Endpoint, "https://") {
+		return &ErrInvalidConfig{Field: "Push.Endpoint", Message: "deve ser uma URL http:// ou https://"}
+	}
+	return nil
+}
+
+// WithPushOnShutdown habilita o modo push: as métricas não são exportadas periodicamente
+// e um único snapshot é enviado ao Pushgateway ou via remote-write no Shutdown.
+func (c Config) WithPushOnShutdown(push PushConfig) Config {
+	c.Push = push
+	return c
+}
+
+// groupingKey retorna job e instance, completando os campos vazios com o resource.
+func (p PushConfig) groupingKey(res *resource.Resource) (job, instance string) {
+	job, instance = p.Job, p.Instance
+	if job == "" {
+		if v, ok := res.Set().Value(semconv.ServiceNameKey); ok {
+			job = v.AsString()
+		}
+	}
+	if instance == "" {
+		if v, ok := res.Set().Value(semconv.ServiceInstanceIDKey); ok {
+			instance = v.AsString()
+		} else if v, ok := res.Set().Value(semconv.HostNameKey); ok {
+			instance = v.AsString()
+		} else {
+			instance, _ = os.Hostname()
+		}
+	}
+	return job, instance
+}
+
+// metricsPusher envia um snapshot das métricas de um Gatherer.
+type metricsPusher struct {
+	config     PushConfig
+	gatherer   promclient.Gatherer
+	job        string
+	instance   string
+	authHeader string
+	httpClient *http.Client
+}
+
+// Push coleta as métricas e as envia no formato configurado.
+func (p *metricsPusher) Push(ctx context.Context) error {
+	if p.config.Format == PushFormatRemoteWrite {
+		return p.remoteWrite(ctx)
+	}
+
+	pusher := push.New(p.config.Endpoint, p.job).
+		Gatherer(p.gatherer).
+		Grouping("instance", p.instance).
+		Client(p.httpClient)
+	if p.authHeader != "" {
+		pusher = pusher.Header(http.Header{"Authorization": {p.authHeader}})
+	}
+	return pusher.PushContext(ctx)
+}
+
+// remoteWrite envia as métricas como uma WriteRequest do Prometheus remote-write 1.0.
+func (p *metricsPusher) remoteWrite(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("falha ao coletar métricas: %w", err)
+	}
+
+	series := familiesToSeries(families, time.Now().UnixMilli(), []remoteLabel{
+		{name: "instance", value: p.instance},
+		{name: "job", value: p.job},
+	})
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if p.authHeader != "" {
+		req.Header.Set("Authorization", p.authHeader)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("falha no remote-write: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote-write retornou status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+type remoteLabel struct {
+	name, value string
+}
+
+type remoteSeries struct {
+	labels    []remoteLabel
+	value     float64
+	timestamp int64
+}
+
+// familiesToSeries converte as famílias em séries do remote-write. Histogramas e summaries
+// geram as séries _bucket/quantile, _sum e _count, como na exposição do Prometheus.
+func familiesToSeries(families []*dto.MetricFamily, timestamp int64, extra []remoteLabel) []remoteSeries {
+	var series []remoteSeries
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.Metric {
+			ts := timestamp
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			add := func(name string, value float64, labels ...remoteLabel) {
+				series = append(series, remoteSeries{
+					labels:    seriesLabels(name, m.GetLabel(), extra, labels),
+					value:     value,
+					timestamp: ts,
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m.GetUntyped().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					add(name+"_bucket", float64(b.GetCumulativeCount()), remoteLabel{name: "le", value: formatLe(b.GetUpperBound())})
+				}
+				add(name+"_bucket", float64(h.GetSampleCount()), remoteLabel{name: "le", value: "+Inf"})
+				add(name+"_sum", h.GetSampleSum())
+				add(name+"_count", float64(h.GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, q.GetValue(), remoteLabel{name: "quantile", value: strconv.FormatFloat(q.GetQuantile(), 'g', -1, 64)})
+				}
+				add(name+"_sum", s.GetSampleSum())
+				add(name+"_count", float64(s.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// seriesLabels monta os labels da série ordenados por nome, como exige o remote-write.
+// Labels da métrica têm precedência sobre os labels extras (job, instance).
+func seriesLabels(name string, pairs []*dto.LabelPair, extra, labels []remoteLabel) []remoteLabel {
+	byName := make(map[string]string, len(pairs)+len(extra)+len(labels)+1)
+	for _, l := range extra {
+		byName[l.name] = l.value
+	}
+	for _, p := range pairs {
+		byName[p.GetName()] = p.GetValue()
+	}
+	for _, l := range labels {
+		byName[l.name] = l.value
+	}
+	byName["__name__"] = name
+
+	result := make([]remoteLabel, 0, len(byName))
+	for n, v := range byName {
+		if v != "" {
+			result = append(result, remoteLabel{name: n, value: v})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result
+}
+
+// formatLe formata o limite de um bucket como no label "le" do Prometheus.
+func formatLe(upper float64) string {
+	return strconv.FormatFloat(upper, 'g', -1, 64)
+}
+
+// encodeWriteRequest serializa a WriteRequest do remote-write:
+//
+//	WriteRequest { repeated TimeSeries timeseries = 1; }
+//	TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	Label        { string name = 1; string value = 2; }
+//	Sample       { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(series []remoteSeries) []byte {
+	var out, ts, msg []byte
+	for _, s := range series {
+		ts = ts[:0]
+		for _, l := range s.labels {
+			msg = msg[:0]
+			msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+			msg = protowire.AppendString(msg, l.name)
+			msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+			msg = protowire.AppendString(msg, l.value)
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, msg)
+		}
+
+		msg = msg[:0]
+		msg = protowire.AppendTag(msg, 1, protowire.Fixed64Type)
+		msg = protowire.AppendFixed64(msg, math.Float64bits(s.value))
+		msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(s.timestamp))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, msg)
+
+		out = protowire.AppendTag(out, 1, protowire.BytesType)
+		out = protowire.AppendBytes(out, ts)
+	}
+	return out
+}
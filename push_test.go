@@ -0,0 +1,257 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// capturedPush guarda a última requisição recebida pelo servidor de teste.
+type capturedPush struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newPushServer(t *testing.T) (*httptest.Server, *capturedPush) {
+	t.Helper()
+	captured := &capturedPush{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.method = r.Method
+		captured.path = r.URL.Path
+		captured.header = r.Header.Clone()
+		captured.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, captured
+}
+
+// decodeWriteRequest decodifica uma WriteRequest em uma lista de séries.
+func decodeWriteRequest(t *testing.T, b []byte) []remoteSeries {
+	t.Helper()
+	var series []remoteSeries
+	fields(t, b, func(_ protowire.Number, v []byte) {
+		var s remoteSeries
+		fields(t, v, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var l remoteLabel
+				fields(t, v, func(num protowire.Number, v []byte) {
+					if num == 1 {
+						l.name = string(v)
+					} else {
+						l.value = string(v)
+					}
+				})
+				s.labels = append(s.labels, l)
+			case 2:
+				bits, n := protowire.ConsumeFixed64(v[1:])
+				if n < 0 {
+					t.Fatal("sample inválido")
+				}
+				s.value = math.Float64frombits(bits)
+				ts, _ := protowire.ConsumeVarint(v[1+n+1:])
+				s.timestamp = int64(ts)
+			}
+		})
+		series = append(series, s)
+	})
+	return series
+}
+
+// fields percorre os campos length-delimited de uma mensagem protobuf.
+func fields(t *testing.T, b []byte, fn func(protowire.Number, []byte)) {
+	t.Helper()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			t.Fatalf("campo inesperado: %d tipo %d", num, typ)
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatal("mensagem truncada")
+		}
+		fn(num, v)
+		b = b[n:]
+	}
+}
+
+func seriesKey(s remoteSeries) string {
+	parts := make([]string, 0, len(s.labels))
+	for _, l := range s.labels {
+		parts = append(parts, l.name+"="+l.value)
+	}
+	return strings.Join(parts, ",")
+}
+
+func initPushClient(t *testing.T, push PushConfig) Client {
+	t.Helper()
+	config := NewConfig("batch-job").WithInsecure(true).WithInstanceID("worker-1").WithPushOnShutdown(push)
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestClient_PushOnShutdown_Pushgateway(t *testing.T) {
+	server, captured := newPushServer(t)
+	client := initPushClient(t, PushConfig{Endpoint: server.URL})
+	ctx := context.Background()
+
+	counter, _ := client.NewMetricsHelper("jobs").NewCounter("rows_processed", "Linhas")
+	counter.Add(ctx, 42, attribute.String("table", "users"))
+
+	if captured.method != "" {
+		t.Fatal("nada deveria ser enviado antes do Shutdown")
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.method != http.MethodPut {
+		t.Errorf("método = %s, esperado PUT", captured.method)
+	}
+	if want := "/metrics/job/batch-job/instance/worker-1"; captured.path != want {
+		t.Errorf("path = %s, esperado %s", captured.path, want)
+	}
+	if !strings.Contains(string(captured.body), "rows_processed_total") {
+		t.Errorf("corpo não contém a métrica rows_processed_total")
+	}
+}
+
+func TestClient_PushOnShutdown_RemoteWrite(t *testing.T) {
+	server, captured := newPushServer(t)
+	client := initPushClient(t, PushConfig{Endpoint: server.URL + "/api/v1/write", Format: PushFormatRemoteWrite, Job: "etl"})
+	ctx := context.Background()
+
+	helper := client.NewMetricsHelper("jobs")
+	counter, _ := helper.NewCounter("rows_processed", "Linhas")
+	counter.Add(ctx, 42, attribute.String("table", "users"))
+	histogram, _ := helper.NewHistogram("batch_size", "Tamanho", WithBuckets(10, 100))
+	histogram.Record(ctx, 50)
+
+	if err := client.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.method != http.MethodPost || captured.path != "/api/v1/write" {
+		t.Errorf("requisição = %s %s", captured.method, captured.path)
+	}
+	for header, want := range map[string]string{
+		"Content-Encoding":                  "snappy",
+		"Content-Type":                      "application/x-protobuf",
+		"X-Prometheus-Remote-Write-Version": "0.1.0",
+	} {
+		if got := captured.header.Get(header); got != want {
+			t.Errorf("header %s = %q, esperado %q", header, got, want)
+		}
+	}
+
+	raw, err := snappy.Decode(nil, captured.body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]float64)
+	for _, s := range decodeWriteRequest(t, raw) {
+		values[seriesKey(s)] = s.value
+		if s.timestamp <= 0 {
+			t.Errorf("série sem timestamp: %s", seriesKey(s))
+		}
+	}
+
+	expected := map[string]float64{
+		"__name__=rows_processed_total,instance=worker-1,job=etl,otel_scope_name=jobs,table=users": 42,
+		"__name__=batch_size_bucket,instance=worker-1,job=etl,le=100,otel_scope_name=jobs":         1,
+		"__name__=batch_size_bucket,instance=worker-1,job=etl,le=+Inf,otel_scope_name=jobs":        1,
+		"__name__=batch_size_sum,instance=worker-1,job=etl,otel_scope_name=jobs":                   50,
+	}
+	for key, want := range expected {
+		got, ok := values[key]
+		if !ok {
+			t.Errorf("série %s não encontrada", key)
+		} else if got != want {
+			t.Errorf("série %s = %v, esperado %v", key, got, want)
+		}
+	}
+}
+
+func TestMetricsPusher_RemoteWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := initPushClient(t, PushConfig{Endpoint: server.URL, Format: PushFormatRemoteWrite})
+	err := client.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "out of order sample") {
+		t.Errorf("esperado erro do remote-write, obtido %v", err)
+	}
+}
+
+func TestPushConfig_GroupingKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        PushConfig
+		attrs         []attribute.KeyValue
+		job, instance string
+	}{
+		{"do resource", PushConfig{}, []attribute.KeyValue{semconv.ServiceName("api"), semconv.ServiceInstanceID("i-1")}, "api", "i-1"},
+		{"host.name", PushConfig{}, []attribute.KeyValue{semconv.ServiceName("api"), semconv.HostName("host-a")}, "api", "host-a"},
+		{"explícito", PushConfig{Job: "etl", Instance: "x"}, []attribute.KeyValue{semconv.ServiceName("api"), semconv.ServiceInstanceID("i-1")}, "etl", "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, instance := tt.config.groupingKey(resource.NewSchemaless(tt.attrs...))
+			if job != tt.job || instance != tt.instance {
+				t.Errorf("groupingKey() = (%q, %q), esperado (%q, %q)", job, instance, tt.job, tt.instance)
+			}
+		})
+	}
+}
+
+func TestConfig_Push(t *testing.T) {
+	t.Setenv("GRAFTEL_PUSH_ENDPOINT", "http://pushgateway:9091")
+	t.Setenv("GRAFTEL_PUSH_FORMAT", "remote_write")
+	t.Setenv("GRAFTEL_PUSH_JOB", "nightly")
+
+	config := NewConfig("test")
+	if config.Push.Endpoint != "http://pushgateway:9091" || config.Push.Format != PushFormatRemoteWrite || config.Push.Job != "nightly" {
+		t.Errorf("Push via ENV = %+v", config.Push)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var invalid *ErrInvalidConfig
+	withPrometheus := config.WithPrometheusEndpoint(":8080")
+	if err := withPrometheus.Validate(); !errors.As(err, &invalid) || invalid.Field != "Push.Endpoint" {
+		t.Errorf("esperado erro ao combinar push e Prometheus, obtido %v", err)
+	}
+
+	noScheme := NewConfig("test").WithPushOnShutdown(PushConfig{Endpoint: "pushgateway:9091"})
+	if err := noScheme.Validate(); !errors.As(err, &invalid) || invalid.Field != "Push.Endpoint" {
+		t.Errorf("esperado erro de endpoint sem esquema, obtido %v", err)
+	}
+
+	if _, err := ParsePushFormat("graphite"); err == nil {
+		t.Error("esperado erro para formato desconhecido")
+	}
+}
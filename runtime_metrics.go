@@ -0,0 +1,470 @@
+package graftel
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/metrics"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Métricas lidas de runtime/metrics.
+const (
+	runtimeGoroutines    = "/sched/goroutines:goroutines"
+	runtimeMemoryTotal   = "/memory/classes/total:bytes"
+	runtimeMemoryFreed   = "/memory/classes/heap/released:bytes"
+	runtimeHeapObjects   = "/memory/classes/heap/objects:bytes"
+	runtimeHeapAllocs    = "/gc/heap/allocs:bytes"
+	runtimeHeapAllocObjs = "/gc/heap/allocs:objects"
+	runtimeHeapGoal      = "/gc/heap/goal:bytes"
+	runtimeMemoryLimit   = "/gc/gomemlimit:bytes"
+	runtimeGOGC          = "/gc/gogc:percent"
+	runtimeGCCycles      = "/gc/cycles/total:gc-cycles"
+	runtimeGCPauses      = "/sched/pauses/total/gc:seconds"
+	runtimeSchedLatency  = "/sched/latencies:seconds"
+	runtimeGOMAXPROCS    = "/sched/gomaxprocs:threads"
+	runtimeCgoCalls      = "/cgo/go-to-c-calls:calls"
+)
+
+// defaultClockTicks é o USER_HZ usado quando /proc/self/auxv não informa AT_CLKTCK. O
+// kernel sempre expõe os tempos de /proc/<pid>/stat em USER_HZ, que é 100 em x86, arm,
+// arm64, ppc, s390 e riscv, independentemente do CONFIG_HZ.
+const defaultClockTicks = 100
+
+// atClockTicks é o tipo AT_CLKTCK do vetor auxiliar (getauxval(3)).
+const atClockTicks = 17
+
+// runtimeCollector lê runtime/metrics e /proc para as métricas de runtime e de processo.
+// É seguro para uso concorrente: cada coleta lê suas próprias amostras.
+type runtimeCollector struct {
+	procDir    string
+	clockTicks float64
+	names      []string
+	index      map[string]int
+}
+
+// newRuntimeCollector cria um coletor que lê as métricas de processo em procDir/self.
+func newRuntimeCollector(procDir string) *runtimeCollector {
+	names := []string{
+		runtimeGoroutines, runtimeMemoryTotal, runtimeMemoryFreed, runtimeHeapObjects,
+		runtimeHeapAllocs, runtimeHeapAllocObjs, runtimeHeapGoal, runtimeMemoryLimit,
+		runtimeGOGC, runtimeGCCycles, runtimeGOMAXPROCS, runtimeCgoCalls,
+	}
+
+	rc := &runtimeCollector{
+		procDir:    procDir,
+		clockTicks: float64(readClockTicks(filepath.Join(procDir, "self", "auxv"))),
+		names:      names,
+		index:      make(map[string]int, len(names)),
+	}
+	for i, name := range names {
+		rc.index[name] = i
+	}
+	return rc
+}
+
+// readClockTicks lê AT_CLKTCK do vetor auxiliar do processo, já que sysconf(_SC_CLK_TCK)
+// exige cgo. Retorna defaultClockTicks se o arquivo não existir ou não tiver a entrada.
+func readClockTicks(auxv string) int64 {
+	data, err := os.ReadFile(auxv)
+	if err != nil {
+		return defaultClockTicks
+	}
+	// Pares (tipo, valor) de palavras nativas, terminados por AT_NULL
+	const word = strconv.IntSize / 8
+	for len(data) >= 2*word {
+		key, value := readWord(data[:word]), readWord(data[word:2*word])
+		data = data[2*word:]
+		if key == 0 {
+			break
+		}
+		if key == atClockTicks && value > 0 && value <= math.MaxInt32 {
+			return int64(value)
+		}
+	}
+	return defaultClockTicks
+}
+
+// readWord decodifica uma palavra de 32 ou 64 bits na ordem de bytes nativa.
+func readWord(b []byte) uint64 {
+	if len(b) == 4 {
+		return uint64(binary.NativeEndian.Uint32(b))
+	}
+	return binary.NativeEndian.Uint64(b)
+}
+
+// runtimeSamples é uma leitura de runtime/metrics.
+type runtimeSamples struct {
+	samples []metrics.Sample
+	index   map[string]int
+}
+
+// read lê runtime/metrics em amostras novas, para que readers coletando ao mesmo tempo
+// não compartilhem o mesmo slice.
+func (rc *runtimeCollector) read() runtimeSamples {
+	samples := make([]metrics.Sample, len(rc.names))
+	for i, name := range rc.names {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+	return runtimeSamples{samples: samples, index: rc.index}
+}
+
+// uint64Value retorna o valor da métrica e se ela é suportada pela versão do Go.
+func (s runtimeSamples) uint64Value(name string) (int64, bool) {
+	v := s.samples[s.index[name]].Value
+	if v.Kind() != metrics.KindUint64 {
+		return 0, false
+	}
+	u := v.Uint64()
+	if u > math.MaxInt64 {
+		u = math.MaxInt64
+	}
+	return int64(u), true
+}
+
+// runtimeHistogram descreve uma distribuição de runtime/metrics publicada como histograma.
+type runtimeHistogram struct {
+	runtimeName string
+	name        string
+	description string
+}
+
+// runtimeHistograms são as distribuições publicadas pelo runtimeProducer.
+var runtimeHistograms = []runtimeHistogram{
+	{runtimeSchedLatency, "go.schedule.duration", "Tempo que goroutines prontas esperaram para executar"},
+	{runtimeGCPauses, "go.gc.pause.duration", "Pausas stop-the-world do GC"},
+}
+
+// runtimeProducer publica as distribuições de runtime/metrics como histogramas
+// cumulativos. A API de métricas não tem histogramas observáveis, por isso elas são
+// anexadas aos readers como um sdkmetric.Producer.
+type runtimeProducer struct {
+	start time.Time
+}
+
+// newRuntimeProducer cria o producer dos histogramas de runtime.
+func newRuntimeProducer() *runtimeProducer {
+	return &runtimeProducer{start: time.Now()}
+}
+
+// Produce implementa sdkmetric.Producer.
+func (p *runtimeProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	samples := make([]metrics.Sample, len(runtimeHistograms))
+	for i, h := range runtimeHistograms {
+		samples[i].Name = h.runtimeName
+	}
+	metrics.Read(samples)
+
+	now := time.Now()
+	out := make([]metricdata.Metrics, 0, len(runtimeHistograms))
+	for i, h := range runtimeHistograms {
+		if samples[i].Value.Kind() != metrics.KindFloat64Histogram {
+			continue
+		}
+		out = append(out, metricdata.Metrics{
+			Name:        h.name,
+			Description: h.description,
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{
+					histogramDataPoint(samples[i].Value.Float64Histogram(), p.start, now),
+				},
+			},
+		})
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: instrumentationName},
+		Metrics: out,
+	}}, nil
+}
+
+// histogramDataPoint converte h em um ponto de histograma. Os limites internos de h viram
+// os limites explícitos; os buckets das pontas, que podem ser infinitos, viram os buckets
+// abertos do OTel. runtime/metrics não expõe a soma, que é estimada pelo ponto médio de
+// cada bucket (ou pelo limite finito, nas pontas).
+func histogramDataPoint(h *metrics.Float64Histogram, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	point := metricdata.HistogramDataPoint[float64]{
+		StartTime:    start,
+		Time:         now,
+		Bounds:       slices.Clone(h.Buckets[1 : len(h.Buckets)-1]),
+		BucketCounts: slices.Clone(h.Counts),
+	}
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		lower, upper := h.Buckets[i], h.Buckets[i+1]
+		var mid float64
+		switch {
+		case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+			mid = 0
+		case math.IsInf(lower, -1):
+			mid = upper
+		case math.IsInf(upper, 1):
+			mid = lower
+		default:
+			mid = (lower + upper) / 2
+		}
+		point.Count += count
+		point.Sum += mid * float64(count)
+	}
+	return point
+}
+
+// processStats são as estatísticas do processo lidas de /proc.
+type processStats struct {
+	UserCPU   float64
+	SystemCPU float64
+	RSS       int64
+	Virtual   int64
+	OpenFDs   int64
+}
+
+// readProcessStats lê CPU de stat, memória de statm e descritores abertos de fd.
+func (rc *runtimeCollector) readProcessStats() (processStats, error) {
+	var stats processStats
+	self := filepath.Join(rc.procDir, "self")
+
+	data, err := os.ReadFile(filepath.Join(self, "stat"))
+	if err != nil {
+		return stats, err
+	}
+	// O nome do processo pode conter espaços e parênteses: os campos começam após o último ')'
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return stats, fmt.Errorf("formato inesperado em %s/stat", self)
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	// Após o nome, utime e stime são o 12º e o 13º campos (14 e 15 em proc(5))
+	if len(fields) < 13 {
+		return stats, fmt.Errorf("formato inesperado em %s/stat", self)
+	}
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	stats.UserCPU = float64(utime) / rc.clockTicks
+	stats.SystemCPU = float64(stime) / rc.clockTicks
+
+	data, err = os.ReadFile(filepath.Join(self, "statm"))
+	if err != nil {
+		return stats, err
+	}
+	fields = strings.Fields(string(data))
+	if len(fields) < 2 {
+		return stats, fmt.Errorf("formato inesperado em %s/statm", self)
+	}
+	pageSize := int64(os.Getpagesize())
+	size, _ := strconv.ParseInt(fields[0], 10, 64)
+	resident, _ := strconv.ParseInt(fields[1], 10, 64)
+	stats.Virtual = size * pageSize
+	stats.RSS = resident * pageSize
+
+	entries, err := os.ReadDir(filepath.Join(self, "fd"))
+	if err != nil {
+		return stats, err
+	}
+	stats.OpenFDs = int64(len(entries))
+
+	return stats, nil
+}
+
+// registerRuntimeMetrics registra as métricas observáveis de runtime Go e, quando /proc
+// estiver disponível, as métricas do processo. As distribuições (go.schedule.duration e
+// go.gc.pause.duration) são publicadas pelo runtimeProducer.
+func registerRuntimeMetrics(meter otelmetric.Meter, procDir string) (otelmetric.Registration, error) {
+	rc := newRuntimeCollector(procDir)
+
+	goroutines, err := meter.Int64ObservableUpDownCounter("go.goroutine.count",
+		otelmetric.WithDescription("Número de goroutines ativas"),
+		otelmetric.WithUnit("{goroutine}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryUsed, err := meter.Int64ObservableUpDownCounter("go.memory.used",
+		otelmetric.WithDescription("Memória usada pelo runtime Go"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	heapUsed, err := meter.Int64ObservableUpDownCounter("go.memory.heap.used",
+		otelmetric.WithDescription("Memória ocupada por objetos no heap, vivos ou ainda não coletados"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated, err := meter.Int64ObservableCounter("go.memory.allocated",
+		otelmetric.WithDescription("Memória alocada no heap"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations, err := meter.Int64ObservableCounter("go.memory.allocations",
+		otelmetric.WithDescription("Número de alocações no heap"),
+		otelmetric.WithUnit("{allocation}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gcGoal, err := meter.Int64ObservableUpDownCounter("go.memory.gc.goal",
+		otelmetric.WithDescription("Tamanho do heap alvo do próximo ciclo de GC"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryLimit, err := meter.Int64ObservableUpDownCounter("go.memory.limit",
+		otelmetric.WithDescription("Limite de memória do runtime (GOMEMLIMIT)"),
+		otelmetric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gogc, err := meter.Int64ObservableUpDownCounter("go.config.gogc",
+		otelmetric.WithDescription("Percentual de crescimento do heap que dispara o GC (GOGC)"),
+		otelmetric.WithUnit("%"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gcCycles, err := meter.Int64ObservableCounter("go.gc.count",
+		otelmetric.WithDescription("Ciclos de GC concluídos"),
+		otelmetric.WithUnit("{gc_cycle}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processors, err := meter.Int64ObservableUpDownCounter("go.processor.limit",
+		otelmetric.WithDescription("Número de threads que executam código Go simultaneamente (GOMAXPROCS)"),
+		otelmetric.WithUnit("{thread}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cgoCalls, err := meter.Int64ObservableCounter("go.cgo.calls",
+		otelmetric.WithDescription("Chamadas de Go para C"),
+		otelmetric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	instruments := []otelmetric.Observable{
+		goroutines, memoryUsed, heapUsed, allocated, allocations, gcGoal, memoryLimit,
+		gogc, gcCycles, processors, cgoCalls,
+	}
+
+	// Métricas de processo dependem de /proc (Linux)
+	_, procErr := rc.readProcessStats()
+	hasProc := procErr == nil
+
+	var cpuTime otelmetric.Float64ObservableCounter
+	var memoryUsage, memoryVirtual, openFDs otelmetric.Int64ObservableUpDownCounter
+	if hasProc {
+		cpuTime, err = meter.Float64ObservableCounter("process.cpu.time",
+			otelmetric.WithDescription("Tempo de CPU consumido pelo processo"),
+			otelmetric.WithUnit("s"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		memoryUsage, err = meter.Int64ObservableUpDownCounter("process.memory.usage",
+			otelmetric.WithDescription("Memória física residente (RSS) do processo"),
+			otelmetric.WithUnit("By"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		memoryVirtual, err = meter.Int64ObservableUpDownCounter("process.memory.virtual",
+			otelmetric.WithDescription("Memória virtual do processo"),
+			otelmetric.WithUnit("By"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		openFDs, err = meter.Int64ObservableUpDownCounter("process.unix.file_descriptor.count",
+			otelmetric.WithDescription("Descritores de arquivo abertos pelo processo"),
+			otelmetric.WithUnit("{file_descriptor}"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		instruments = append(instruments, cpuTime, memoryUsage, memoryVirtual, openFDs)
+	}
+
+	userMode := otelmetric.WithAttributes(attribute.String("cpu.mode", "user"))
+	systemMode := otelmetric.WithAttributes(attribute.String("cpu.mode", "system"))
+
+	return meter.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		samples := rc.read()
+
+		observe := func(inst otelmetric.Int64Observable, name string) {
+			if v, ok := samples.uint64Value(name); ok {
+				o.ObserveInt64(inst, v)
+			}
+		}
+		observe(goroutines, runtimeGoroutines)
+		observe(heapUsed, runtimeHeapObjects)
+		observe(allocated, runtimeHeapAllocs)
+		observe(allocations, runtimeHeapAllocObjs)
+		observe(gcGoal, runtimeHeapGoal)
+		observe(memoryLimit, runtimeMemoryLimit)
+		observe(gogc, runtimeGOGC)
+		observe(gcCycles, runtimeGCCycles)
+		observe(processors, runtimeGOMAXPROCS)
+		observe(cgoCalls, runtimeCgoCalls)
+
+		total, okTotal := samples.uint64Value(runtimeMemoryTotal)
+		released, okReleased := samples.uint64Value(runtimeMemoryFreed)
+		if okTotal && okReleased {
+			o.ObserveInt64(memoryUsed, total-released)
+		}
+
+		if !hasProc {
+			return nil
+		}
+		stats, err := rc.readProcessStats()
+		if err != nil {
+			return fmt.Errorf("falha ao ler métricas do processo: %w", err)
+		}
+		o.ObserveFloat64(cpuTime, stats.UserCPU, userMode)
+		o.ObserveFloat64(cpuTime, stats.SystemCPU, systemMode)
+		o.ObserveInt64(memoryUsage, stats.RSS)
+		o.ObserveInt64(memoryVirtual, stats.Virtual)
+		o.ObserveInt64(openFDs, stats.OpenFDs)
+		return nil
+	}, instruments...)
+}
@@ -0,0 +1,228 @@
+package graftel
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/metrics"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// writeFakeProc cria um /proc/self mínimo com stat, statm e três descritores.
+func writeFakeProc(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	self := filepath.Join(dir, "self")
+	if err := os.MkdirAll(filepath.Join(self, "fd"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	stat := "1234 (my (weird) app) S 1 1234 1234 0 -1 4194560 100 0 0 0 250 75 0 0 20 0 8 0 100 1000 200 0\n"
+	files := map[string]string{
+		"stat":  stat,
+		"statm": "1000 200 50 10 0 300 0\n",
+		"fd/0":  "",
+		"fd/1":  "",
+		"fd/2":  "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(self, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRuntimeCollector_ReadProcessStats(t *testing.T) {
+	rc := newRuntimeCollector(writeFakeProc(t))
+
+	stats, err := rc.readProcessStats()
+	if err != nil {
+		t.Fatalf("readProcessStats() erro = %v", err)
+	}
+
+	pageSize := int64(os.Getpagesize())
+	if stats.UserCPU != 2.5 || stats.SystemCPU != 0.75 {
+		t.Errorf("CPU = %v/%v, esperado 2.5/0.75", stats.UserCPU, stats.SystemCPU)
+	}
+	if stats.RSS != 200*pageSize || stats.Virtual != 1000*pageSize {
+		t.Errorf("memória = %d/%d", stats.RSS, stats.Virtual)
+	}
+	if stats.OpenFDs != 3 {
+		t.Errorf("OpenFDs = %d, esperado 3", stats.OpenFDs)
+	}
+}
+
+func TestReadClockTicks(t *testing.T) {
+	auxv := filepath.Join(t.TempDir(), "auxv")
+	word := strconv.IntSize / 8
+	data := make([]byte, 6*word)
+	put := func(i int, v uint64) {
+		if word == 4 {
+			binary.NativeEndian.PutUint32(data[i*word:], uint32(v))
+		} else {
+			binary.NativeEndian.PutUint64(data[i*word:], v)
+		}
+	}
+	put(0, 6) // AT_PAGESZ
+	put(1, 4096)
+	put(2, atClockTicks)
+	put(3, 250)
+	if err := os.WriteFile(auxv, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readClockTicks(auxv); got != 250 {
+		t.Errorf("readClockTicks() = %d, esperado 250", got)
+	}
+	if got := readClockTicks(filepath.Join(t.TempDir(), "missing")); got != defaultClockTicks {
+		t.Errorf("readClockTicks() sem auxv = %d, esperado %d", got, defaultClockTicks)
+	}
+}
+
+func TestHistogramDataPoint(t *testing.T) {
+	h := &metrics.Float64Histogram{
+		Counts:  []uint64{1, 5, 4, 2},
+		Buckets: []float64{math.Inf(-1), 0, 0.002, 0.01, math.Inf(1)},
+	}
+
+	point := histogramDataPoint(h, time.Time{}, time.Now())
+	if !slices.Equal(point.Bounds, []float64{0, 0.002, 0.01}) {
+		t.Errorf("Bounds = %v", point.Bounds)
+	}
+	if !slices.Equal(point.BucketCounts, h.Counts) {
+		t.Errorf("BucketCounts = %v", point.BucketCounts)
+	}
+	if point.Count != 12 {
+		t.Errorf("Count = %d, esperado 12", point.Count)
+	}
+	// Pontas usam o limite finito; buckets internos, o ponto médio
+	want := 1*0 + 5*0.001 + 4*0.006 + 2*0.01
+	if math.Abs(point.Sum-want) > 1e-12 {
+		t.Errorf("Sum = %v, esperado %v", point.Sum, want)
+	}
+}
+
+func TestRuntimeProducer(t *testing.T) {
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(newRuntimeProducer()))
+	sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	got := collectMetrics(t, reader)
+	for _, name := range []string{"go.schedule.duration", "go.gc.pause.duration"} {
+		data, ok := got[name].Data.(metricdata.Histogram[float64])
+		if !ok {
+			t.Fatalf("%s: esperado histograma, obtido %T", name, got[name].Data)
+		}
+		if data.Temporality != metricdata.CumulativeTemporality || len(data.DataPoints) != 1 {
+			t.Errorf("%s: histograma inesperado: %+v", name, data)
+		}
+		if got[name].Unit != "s" {
+			t.Errorf("%s: unidade = %q", name, got[name].Unit)
+		}
+	}
+}
+
+func TestRegisterRuntimeMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	reg, err := registerRuntimeMetrics(provider.Meter("test"), writeFakeProc(t))
+	if err != nil {
+		t.Fatalf("registerRuntimeMetrics() erro = %v", err)
+	}
+	defer reg.Unregister()
+
+	got := collectMetrics(t, reader)
+	for _, name := range []string{
+		"go.goroutine.count", "go.memory.used", "go.memory.allocated", "go.gc.count",
+		"go.processor.limit",
+		"process.cpu.time", "process.memory.usage", "process.unix.file_descriptor.count",
+	} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("métrica %s não encontrada", name)
+		}
+	}
+
+	goroutines := got["go.goroutine.count"].Data.(metricdata.Sum[int64])
+	if goroutines.DataPoints[0].Value < 1 {
+		t.Errorf("go.goroutine.count = %d", goroutines.DataPoints[0].Value)
+	}
+
+	cpu := got["process.cpu.time"].Data.(metricdata.Sum[float64])
+	for _, dp := range cpu.DataPoints {
+		mode, _ := dp.Attributes.Value(attribute.Key("cpu.mode"))
+		if mode.AsString() == "user" && dp.Value != 2.5 {
+			t.Errorf("process.cpu.time{cpu.mode=user} = %v, esperado 2.5", dp.Value)
+		}
+	}
+}
+
+func TestRegisterRuntimeMetrics_ParallelReaders(t *testing.T) {
+	first := sdkmetric.NewManualReader()
+	second := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(first), sdkmetric.WithReader(second))
+
+	reg, err := registerRuntimeMetrics(provider.Meter("test"), writeFakeProc(t))
+	if err != nil {
+		t.Fatalf("registerRuntimeMetrics() erro = %v", err)
+	}
+	defer reg.Unregister()
+
+	// Com -race, detecta amostras compartilhadas entre coletas simultâneas
+	var wg sync.WaitGroup
+	for _, reader := range []sdkmetric.Reader{first, second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				var rm metricdata.ResourceMetrics
+				if err := reader.Collect(context.Background(), &rm); err != nil {
+					t.Errorf("Collect() erro = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRegisterRuntimeMetrics_WithoutProc(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	reg, err := registerRuntimeMetrics(provider.Meter("test"), t.TempDir())
+	if err != nil {
+		t.Fatalf("registerRuntimeMetrics() erro = %v", err)
+	}
+	defer reg.Unregister()
+
+	got := collectMetrics(t, reader)
+	if _, ok := got["process.cpu.time"]; ok {
+		t.Error("métricas de processo não devem ser registradas sem /proc")
+	}
+	if _, ok := got["go.goroutine.count"]; !ok {
+		t.Error("métricas de runtime devem ser registradas sem /proc")
+	}
+}
+
+func TestConfig_RuntimeMetrics(t *testing.T) {
+	os.Setenv("GRAFTEL_RUNTIME_METRICS", "true")
+	defer os.Unsetenv("GRAFTEL_RUNTIME_METRICS")
+
+	if !NewConfig("test-service").RuntimeMetrics {
+		t.Error("RuntimeMetrics = false, esperado true via ENV")
+	}
+	if !NewConfig("test-service").WithRuntimeMetrics(true).RuntimeMetrics {
+		t.Error("WithRuntimeMetrics(true) não habilitou as métricas")
+	}
+}
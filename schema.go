@@ -0,0 +1,341 @@
+package graftel
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unsafe"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// ErrSchemaViolation é reportado quando uma medição não respeita o schema da métrica.
+// Por padrão a medição é descartada e o erro enviado ao handler do OpenTelemetry; com
+// schemas estritos (Config.StrictMetricSchemas ou WithStrictSchemas) a medição causa
+// panic com este erro, o que é útil em testes.
+type ErrSchemaViolation struct {
+	Instrument string
+	Key        string
+	Reason     string
+}
+
+func (e *ErrSchemaViolation) Error() string {
+	return fmt.Sprintf("schema da métrica '%s': atributo '%s' %s", e.Instrument, e.Key, e.Reason)
+}
+
+// schemaField é um campo da struct de labels mapeado para um atributo. O layout
+// (offset e leitor do tipo) é calculado uma vez em newMetricSchema, de forma que as
+// medições leem os campos sem reflection.
+type schemaField struct {
+	offset   uintptr
+	read     func(unsafe.Pointer) attribute.Value
+	zero     attribute.Value
+	key      attribute.Key
+	kind     attribute.Type
+	required bool
+}
+
+// metricSchema descreve os atributos permitidos de uma métrica a partir de uma struct de labels.
+type metricSchema struct {
+	instrument string
+	strict     bool
+	fields     []schemaField
+	byKey      map[attribute.Key]schemaField
+}
+
+// newMetricSchema lê os campos exportados de L. A chave do atributo vem da tag
+// `metric:"chave"` (ou do nome do campo em snake_case); a opção `required`
+// (`metric:"chave,required"`) rejeita o valor zero e `metric:"-"` ignora o campo.
+// O schema é estrito se m foi criado com schemas estritos.
+func newMetricSchema[L any](m MetricsHelper, instrument string) (*metricSchema, error) {
+	t := reflect.TypeOf((*L)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema da métrica '%s': labels devem ser uma struct, obtido %s", instrument, t)
+	}
+
+	schema := &metricSchema{
+		instrument: instrument,
+		byKey:      make(map[attribute.Key]schemaField),
+	}
+	if helper, ok := m.(*metricsHelper); ok {
+		schema.strict = helper.strictSchemas
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("metric")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = toSnakeCase(f.Name)
+		}
+
+		read, ok := fieldReader(f.Type.Kind())
+		if !ok {
+			return nil, fmt.Errorf("schema da métrica '%s': campo %s tem tipo não suportado %s", instrument, f.Name, f.Type)
+		}
+
+		key := attribute.Key(name)
+		if _, dup := schema.byKey[key]; dup {
+			return nil, fmt.Errorf("schema da métrica '%s': atributo '%s' duplicado", instrument, name)
+		}
+
+		zero := read(reflect.New(f.Type).UnsafePointer())
+		field := schemaField{
+			offset:   f.Offset,
+			read:     read,
+			zero:     zero,
+			key:      key,
+			kind:     zero.Type(),
+			required: options == "required",
+		}
+		schema.fields = append(schema.fields, field)
+		schema.byKey[key] = field
+	}
+	return schema, nil
+}
+
+// fieldReader retorna a função que lê um campo do tipo k como valor de atributo.
+func fieldReader(k reflect.Kind) (func(unsafe.Pointer) attribute.Value, bool) {
+	switch k {
+	case reflect.String:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.StringValue(*(*string)(p)) }, true
+	case reflect.Bool:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.BoolValue(*(*bool)(p)) }, true
+	case reflect.Int:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*int)(p))) }, true
+	case reflect.Int8:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*int8)(p))) }, true
+	case reflect.Int16:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*int16)(p))) }, true
+	case reflect.Int32:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*int32)(p))) }, true
+	case reflect.Int64:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(*(*int64)(p)) }, true
+	case reflect.Uint8:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*uint8)(p))) }, true
+	case reflect.Uint16:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*uint16)(p))) }, true
+	case reflect.Uint32:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Int64Value(int64(*(*uint32)(p))) }, true
+	case reflect.Float32:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Float64Value(float64(*(*float32)(p))) }, true
+	case reflect.Float64:
+		return func(p unsafe.Pointer) attribute.Value { return attribute.Float64Value(*(*float64)(p)) }, true
+	default:
+		return nil, false
+	}
+}
+
+// toSnakeCase converte "StatusCode" em "status_code".
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// attributes converte a struct de labels apontada por labels em atributos, validando
+// os campos obrigatórios.
+func (s *metricSchema) attributes(labels unsafe.Pointer) ([]attribute.KeyValue, error) {
+	attrs := make([]attribute.KeyValue, 0, len(s.fields))
+	for _, f := range s.fields {
+		value := f.read(unsafe.Add(labels, f.offset))
+		if f.required && value == f.zero {
+			return nil, &ErrSchemaViolation{Instrument: s.instrument, Key: string(f.key), Reason: "obrigatório ausente"}
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: f.key, Value: value})
+	}
+	return attrs, nil
+}
+
+// validate verifica atributos livres contra o schema: chaves desconhecidas,
+// tipos divergentes e campos obrigatórios ausentes.
+func (s *metricSchema) validate(attrs []attribute.KeyValue) error {
+	seen := make(map[attribute.Key]bool, len(attrs))
+	for _, kv := range attrs {
+		f, ok := s.byKey[kv.Key]
+		if !ok {
+			return &ErrSchemaViolation{Instrument: s.instrument, Key: string(kv.Key), Reason: "desconhecido"}
+		}
+		if kv.Value.Type() != f.kind {
+			return &ErrSchemaViolation{
+				Instrument: s.instrument,
+				Key:        string(kv.Key),
+				Reason:     fmt.Sprintf("com tipo %s, esperado %s", kv.Value.Type(), f.kind),
+			}
+		}
+		seen[kv.Key] = true
+	}
+	for _, f := range s.fields {
+		if f.required && !seen[f.key] {
+			return &ErrSchemaViolation{Instrument: s.instrument, Key: string(f.key), Reason: "obrigatório ausente"}
+		}
+	}
+	return nil
+}
+
+// reject trata uma violação de schema: panic em schemas estritos, descarte com aviso nos demais.
+func (s *metricSchema) reject(err error) {
+	if s.strict {
+		panic(err)
+	}
+	otel.Handle(err)
+}
+
+// resolve converte labels em atributos. Retorna false se a medição deve ser descartada.
+func (s *metricSchema) resolve(labels unsafe.Pointer) ([]attribute.KeyValue, bool) {
+	attrs, err := s.attributes(labels)
+	if err != nil {
+		s.reject(err)
+		return nil, false
+	}
+	return attrs, true
+}
+
+// check valida atributos livres. Retorna false se a medição deve ser descartada.
+func (s *metricSchema) check(attrs []attribute.KeyValue) bool {
+	if err := s.validate(attrs); err != nil {
+		s.reject(err)
+		return false
+	}
+	return true
+}
+
+// CounterVec é um contador com atributos declarados pela struct L.
+//
+//	type RequestLabels struct {
+//		Method string `metric:"method,required"`
+//		Status int    `metric:"status"`
+//	}
+//
+//	requests, err := graftel.NewCounterVec[RequestLabels](metrics, "http_requests_total", "Requisições")
+//	requests.Add(ctx, 1, RequestLabels{Method: "GET", Status: 200})
+type CounterVec[L any] struct {
+	counter *Counter
+	schema  *metricSchema
+}
+
+// NewCounterVec cria um contador cujos atributos são definidos pela struct L.
+func NewCounterVec[L any](m MetricsHelper, name, description string, opts ...otelmetric.Int64CounterOption) (*CounterVec[L], error) {
+	schema, err := newMetricSchema[L](m, name)
+	if err != nil {
+		return nil, err
+	}
+	counter, err := m.NewCounter(name, description, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CounterVec[L]{counter: counter, schema: schema}, nil
+}
+
+// Add incrementa o contador pelo valor especificado.
+func (c *CounterVec[L]) Add(ctx context.Context, value int64, labels L) {
+	if attrs, ok := c.schema.resolve(unsafe.Pointer(&labels)); ok {
+		c.counter.Add(ctx, value, attrs...)
+	}
+}
+
+// Increment incrementa o contador em 1.
+func (c *CounterVec[L]) Increment(ctx context.Context, labels L) {
+	c.Add(ctx, 1, labels)
+}
+
+// AddAttributes incrementa o contador com atributos livres validados contra o schema,
+// útil para migrar chamadas existentes.
+func (c *CounterVec[L]) AddAttributes(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
+	if c.schema.check(attrs) {
+		c.counter.Add(ctx, value, attrs...)
+	}
+}
+
+// UpDownCounterVec é um up-down counter com atributos declarados pela struct L.
+type UpDownCounterVec[L any] struct {
+	counter *UpDownCounter
+	schema  *metricSchema
+}
+
+// NewUpDownCounterVec cria um up-down counter cujos atributos são definidos pela struct L.
+func NewUpDownCounterVec[L any](m MetricsHelper, name, description string, opts ...otelmetric.Int64UpDownCounterOption) (*UpDownCounterVec[L], error) {
+	schema, err := newMetricSchema[L](m, name)
+	if err != nil {
+		return nil, err
+	}
+	counter, err := m.NewUpDownCounter(name, description, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &UpDownCounterVec[L]{counter: counter, schema: schema}, nil
+}
+
+// Add adiciona (ou subtrai) um valor ao contador.
+func (u *UpDownCounterVec[L]) Add(ctx context.Context, value int64, labels L) {
+	if attrs, ok := u.schema.resolve(unsafe.Pointer(&labels)); ok {
+		u.counter.Add(ctx, value, attrs...)
+	}
+}
+
+// AddAttributes adiciona um valor com atributos livres validados contra o schema.
+func (u *UpDownCounterVec[L]) AddAttributes(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
+	if u.schema.check(attrs) {
+		u.counter.Add(ctx, value, attrs...)
+	}
+}
+
+// HistogramVec é um histograma com atributos declarados pela struct L.
+type HistogramVec[L any] struct {
+	histogram *Histogram
+	schema    *metricSchema
+}
+
+// NewHistogramVec cria um histograma cujos atributos são definidos pela struct L.
+func NewHistogramVec[L any](m MetricsHelper, name, description string, opts ...otelmetric.Float64HistogramOption) (*HistogramVec[L], error) {
+	schema, err := newMetricSchema[L](m, name)
+	if err != nil {
+		return nil, err
+	}
+	histogram, err := m.NewHistogram(name, description, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &HistogramVec[L]{histogram: histogram, schema: schema}, nil
+}
+
+// Record registra um valor no histograma.
+func (h *HistogramVec[L]) Record(ctx context.Context, value float64, labels L) {
+	if attrs, ok := h.schema.resolve(unsafe.Pointer(&labels)); ok {
+		h.histogram.Record(ctx, value, attrs...)
+	}
+}
+
+// RecordDuration registra uma duração na unidade do histograma.
+func (h *HistogramVec[L]) RecordDuration(ctx context.Context, duration time.Duration, labels L) {
+	if attrs, ok := h.schema.resolve(unsafe.Pointer(&labels)); ok {
+		h.histogram.RecordDuration(ctx, duration, attrs...)
+	}
+}
+
+// RecordAttributes registra um valor com atributos livres validados contra o schema.
+func (h *HistogramVec[L]) RecordAttributes(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	if h.schema.check(attrs) {
+		h.histogram.Record(ctx, value, attrs...)
+	}
+}
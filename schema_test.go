@@ -0,0 +1,239 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type requestLabels struct {
+	Method     string `metric:"method,required"`
+	StatusCode int
+	Cached     bool    `metric:"cache_hit"`
+	Ratio      float64 `metric:"-"`
+	internal   string
+}
+
+// expectSchemaPanic verifica que fn causa panic com *ErrSchemaViolation para key.
+func expectSchemaPanic(t *testing.T, key string, fn func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		var violation *ErrSchemaViolation
+		if !ok || !errors.As(err, &violation) {
+			t.Fatalf("esperado panic com *ErrSchemaViolation, obtido %v", r)
+		}
+		if violation.Key != key {
+			t.Errorf("violação em '%s', esperado '%s'", violation.Key, key)
+		}
+	}()
+	fn()
+}
+
+func TestNewMetricSchema(t *testing.T) {
+	schema, err := newMetricSchema[requestLabels](nil, "requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []attribute.Key
+	for _, f := range schema.fields {
+		keys = append(keys, f.key)
+	}
+	want := []attribute.Key{"method", "status_code", "cache_hit"}
+	if len(keys) != len(want) {
+		t.Fatalf("chaves = %v, esperado %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("chave[%d] = %s, esperado %s", i, keys[i], want[i])
+		}
+	}
+
+	if _, err := newMetricSchema[string](nil, "x"); err == nil {
+		t.Error("esperado erro para labels que não são struct")
+	}
+	if _, err := newMetricSchema[struct{ Tags []string }](nil, "x"); err == nil {
+		t.Error("esperado erro para tipo de campo não suportado")
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Method":     "method",
+		"StatusCode": "status_code",
+		"HTTPMethod": "http_method",
+		"UserID":     "user_id",
+	}
+	for in, want := range tests {
+		if got := toSnakeCase(in); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCounterVec_Add(t *testing.T) {
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"))
+
+	requests, err := NewCounterVec[requestLabels](helper, "http_requests_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests.Add(context.Background(), 2, requestLabels{Method: "GET", StatusCode: 200, Cached: true})
+
+	dp := collectMetrics(t, reader)["http_requests_total"].Data.(metricdata.Sum[int64]).DataPoints[0]
+	if dp.Value != 2 {
+		t.Errorf("valor = %d, esperado 2", dp.Value)
+	}
+	if v, _ := dp.Attributes.Value("status_code"); v.AsInt64() != 200 {
+		t.Errorf("status_code = %v", v.Emit())
+	}
+	if v, _ := dp.Attributes.Value("cache_hit"); !v.AsBool() {
+		t.Error("cache_hit deveria ser true")
+	}
+	if dp.Attributes.Len() != 3 {
+		t.Errorf("esperado 3 atributos, obtido %v", dp.Attributes.ToSlice())
+	}
+}
+
+func TestCounterVec_Strict(t *testing.T) {
+	helper := NewMetricsHelper(metric.NewMeterProvider().Meter("test"), WithStrictSchemas())
+	requests, err := NewCounterVec[requestLabels](helper, "http_requests_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	expectSchemaPanic(t, "method", func() {
+		requests.Increment(ctx, requestLabels{StatusCode: 200})
+	})
+	expectSchemaPanic(t, "stauts", func() {
+		requests.AddAttributes(ctx, 1, attribute.String("method", "GET"), attribute.Int("stauts", 200))
+	})
+	expectSchemaPanic(t, "status_code", func() {
+		requests.AddAttributes(ctx, 1, attribute.String("method", "GET"), attribute.String("status_code", "200"))
+	})
+	expectSchemaPanic(t, "method", func() {
+		requests.AddAttributes(ctx, 1, attribute.Int("status_code", 200))
+	})
+}
+
+func TestHistogramVec_DropsByDefault(t *testing.T) {
+	errs := captureErrors(t)
+
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"))
+	latency, err := NewHistogramVec[requestLabels](helper, "latency", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	latency.Record(ctx, 0.1, requestLabels{Method: "GET"})
+	latency.Record(ctx, 0.2, requestLabels{})
+	latency.RecordAttributes(ctx, 0.3, attribute.String("method", "GET"), attribute.String("path", "/x"))
+
+	dp := collectMetrics(t, reader)["latency"].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if dp.Count != 1 {
+		t.Errorf("esperado apenas a medição válida, obtido %d", dp.Count)
+	}
+
+	got := errs()
+	if len(got) != 2 {
+		t.Fatalf("esperado 2 avisos, obtido %v", got)
+	}
+	var violation *ErrSchemaViolation
+	if !errors.As(got[1], &violation) || violation.Key != "path" {
+		t.Errorf("aviso inesperado: %v", got[1])
+	}
+}
+
+func TestUpDownCounterVec_Add(t *testing.T) {
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"))
+
+	type poolLabels struct {
+		Pool string `metric:"pool,required"`
+	}
+	inUse, err := NewUpDownCounterVec[poolLabels](helper, "connections_in_use", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inUse.Add(context.Background(), 3, poolLabels{Pool: "primary"})
+	inUse.Add(context.Background(), -1, poolLabels{Pool: "primary"})
+
+	dp := collectMetrics(t, reader)["connections_in_use"].Data.(metricdata.Sum[int64]).DataPoints[0]
+	if dp.Value != 2 {
+		t.Errorf("valor = %d, esperado 2", dp.Value)
+	}
+}
+
+func TestMetricSchema_FieldKinds(t *testing.T) {
+	type labels struct {
+		Small  int8
+		Medium uint16
+		Large  int64
+		Ratio  float32
+		Shard  uint8 `metric:"shard,required"`
+	}
+	schema, err := newMetricSchema[labels](nil, "kinds")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := labels{Small: -3, Medium: 60000, Large: 1 << 40, Ratio: 0.5, Shard: 7}
+	attrs, err := schema.attributes(unsafe.Pointer(&l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := attribute.NewSet(attrs...)
+	for key, want := range map[attribute.Key]attribute.Value{
+		"small":  attribute.Int64Value(-3),
+		"medium": attribute.Int64Value(60000),
+		"large":  attribute.Int64Value(1 << 40),
+		"ratio":  attribute.Float64Value(0.5),
+		"shard":  attribute.Int64Value(7),
+	} {
+		if got, _ := set.Value(key); got != want {
+			t.Errorf("%s = %v, esperado %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	l.Shard = 0
+	if _, err := schema.attributes(unsafe.Pointer(&l)); err == nil {
+		t.Error("esperado erro para campo obrigatório com valor zero")
+	}
+}
+
+func TestClient_StrictMetricSchemas(t *testing.T) {
+	cl, err := NewClient(NewConfig("test-service").WithStrictMetricSchemas(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests, err := NewCounterVec[requestLabels](cl.NewMetricsHelper("test"), "strict_requests_total", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectSchemaPanic(t, "method", func() {
+		requests.Increment(context.Background(), requestLabels{})
+	})
+}
+
+func BenchmarkCounterVec_Add(b *testing.B) {
+	helper, _ := newTestMetricsHelper(b)
+	requests, _ := NewCounterVec[requestLabels](helper, "requests_total", "Requisições")
+	ctx := context.Background()
+	labels := requestLabels{Method: "GET", StatusCode: 200}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		requests.Add(ctx, 1, labels)
+	}
+}
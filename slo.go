@@ -0,0 +1,421 @@
+package graftel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// DefaultSLOWindow é a janela padrão de avaliação de um SLO.
+const DefaultSLOWindow = 30 * 24 * time.Hour
+
+const (
+	// SLOKey identifica o SLO nas métricas geradas pelo SLOTracker.
+	SLOKey = attribute.Key("slo")
+	// SLOWindowKey identifica a janela das medições de burn rate.
+	SLOWindowKey = attribute.Key("window")
+)
+
+// SLO declara um objetivo de nível de serviço sobre as requisições HTTP.
+// Uma requisição é um evento bom quando o status é menor que 500 e, se
+// LatencyThreshold for definido, a duração não ultrapassa o limite.
+//
+//	graftel.SLO{
+//		Name:             "checkout-latency",
+//		Path:             "/checkout",
+//		Objective:        0.999,
+//		LatencyThreshold: 300 * time.Millisecond,
+//	}
+type SLO struct {
+	// Name identifica o SLO no atributo "slo" e nas regras do Prometheus.
+	Name string
+	// Method restringe o SLO a um método HTTP. Vazio considera todos.
+	Method string
+	// Path restringe o SLO a uma rota, como registrada pelo middleware. Vazio considera todas.
+	Path string
+	// Objective é a fração de eventos bons esperada, entre 0 e 1 (ex: 0.999).
+	Objective float64
+	// LatencyThreshold é a duração máxima de um evento bom. Zero avalia apenas disponibilidade.
+	LatencyThreshold time.Duration
+	// Window é a janela do error budget, de pelo menos uma hora, contada em horas
+	// inteiras (arredondada para cima). Padrão: DefaultSLOWindow (30 dias).
+	Window time.Duration
+}
+
+// matches indica se a requisição pertence ao SLO.
+func (s SLO) matches(method, path string) bool {
+	return (s.Method == "" || s.Method == method) && (s.Path == "" || s.Path == path)
+}
+
+// good indica se a requisição é um evento bom.
+func (s SLO) good(status int, duration time.Duration) bool {
+	return status < 500 && (s.LatencyThreshold == 0 || duration <= s.LatencyThreshold)
+}
+
+// errorBudget é a fração de eventos ruins tolerada.
+func (s SLO) errorBudget() float64 {
+	return 1 - s.Objective
+}
+
+func (s SLO) validate() error {
+	field := func(name string) string { return fmt.Sprintf("SLO[%s].%s", s.Name, name) }
+
+	switch {
+	case s.Name == "":
+		return &ErrInvalidConfig{Field: "SLO.Name", Message: "não pode estar vazio"}
+	case s.Objective <= 0 || s.Objective >= 1:
+		return &ErrInvalidConfig{Field: field("Objective"), Message: "deve estar entre 0 e 1 (exclusivo)"}
+	case s.LatencyThreshold < 0:
+		return &ErrInvalidConfig{Field: field("LatencyThreshold"), Message: "não pode ser negativo"}
+	case s.Window < 0:
+		return &ErrInvalidConfig{Field: field("Window"), Message: "não pode ser negativo"}
+	case s.Window != 0 && s.Window < time.Hour:
+		// O error budget é contado em buckets de uma hora
+		return &ErrInvalidConfig{Field: field("Window"), Message: "deve ser de pelo menos 1h"}
+	}
+	return nil
+}
+
+// sloAlert é uma combinação de janelas longa e curta do alerta de burn rate multi-janela.
+type sloAlert struct {
+	long, short time.Duration
+	burnRate    float64
+	severity    string
+}
+
+// sloAlerts segue os alertas multi-janela recomendados pelo SRE Workbook:
+// 2% do budget em 1h e 5% em 6h geram page; 10% em 3 dias geram ticket.
+var sloAlerts = []sloAlert{
+	{long: time.Hour, short: 5 * time.Minute, burnRate: 14.4, severity: "page"},
+	{long: 6 * time.Hour, short: 30 * time.Minute, burnRate: 6, severity: "page"},
+	{long: 24 * time.Hour, short: 2 * time.Hour, burnRate: 3, severity: "ticket"},
+	{long: 72 * time.Hour, short: 6 * time.Hour, burnRate: 1, severity: "ticket"},
+}
+
+// sloBurnWindows são as janelas em que o burn rate é calculado e publicado.
+// A maior delas define o tamanho do ring buffer por minuto.
+var sloBurnWindows = []time.Duration{
+	5 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour, 6 * time.Hour, 24 * time.Hour, 72 * time.Hour,
+}
+
+// sloBucket acumula os eventos de um intervalo da janela.
+type sloBucket struct {
+	slot        int64
+	good, total int64
+}
+
+// sloWindow é um ring buffer de contagens de eventos com resolução fixa.
+type sloWindow struct {
+	resolution time.Duration
+	buckets    []sloBucket
+}
+
+func newSLOWindow(resolution, span time.Duration) *sloWindow {
+	n := int(span / resolution)
+	if span%resolution != 0 {
+		n++
+	}
+	return &sloWindow{resolution: resolution, buckets: make([]sloBucket, n)}
+}
+
+func (w *sloWindow) add(now time.Time, good bool) {
+	slot := now.UnixNano() / int64(w.resolution)
+	b := &w.buckets[slot%int64(len(w.buckets))]
+	if b.slot != slot {
+		*b = sloBucket{slot: slot}
+	}
+	b.total++
+	if good {
+		b.good++
+	}
+}
+
+// sum retorna os eventos dos últimos span, incluindo o intervalo atual. span é
+// arredondado para cima até um múltiplo da resolução.
+func (w *sloWindow) sum(now time.Time, span time.Duration) (good, total int64) {
+	n := int64(span / w.resolution)
+	if span%w.resolution != 0 {
+		n++
+	}
+	if n > int64(len(w.buckets)) {
+		n = int64(len(w.buckets))
+	}
+	current := now.UnixNano() / int64(w.resolution)
+	for slot := current - n + 1; slot <= current; slot++ {
+		if b := w.buckets[slot%int64(len(w.buckets))]; b.slot == slot {
+			good += b.good
+			total += b.total
+		}
+	}
+	return good, total
+}
+
+// sloState guarda as contagens de um SLO em duas resoluções: minutos para as
+// janelas de burn rate e horas para o error budget da janela completa.
+type sloState struct {
+	slo   SLO
+	good  *BoundCounter
+	total *BoundCounter
+
+	mu     sync.Mutex
+	burn   *sloWindow
+	budget *sloWindow
+}
+
+func (s *sloState) record(ctx context.Context, now time.Time, good bool) {
+	s.total.Increment(ctx)
+	if good {
+		s.good.Increment(ctx)
+	}
+
+	s.mu.Lock()
+	s.burn.add(now, good)
+	s.budget.add(now, good)
+	s.mu.Unlock()
+}
+
+// errorRatio retorna a fração de eventos ruins em span, ou 0 sem eventos.
+func errorRatio(good, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(total-good) / float64(total)
+}
+
+func (s *sloState) burnRate(now time.Time, span time.Duration) float64 {
+	s.mu.Lock()
+	good, total := s.burn.sum(now, span)
+	s.mu.Unlock()
+	return errorRatio(good, total) / s.slo.errorBudget()
+}
+
+func (s *sloState) budgetRemaining(now time.Time) float64 {
+	s.mu.Lock()
+	good, total := s.budget.sum(now, s.slo.Window)
+	s.mu.Unlock()
+	return 1 - errorRatio(good, total)/s.slo.errorBudget()
+}
+
+// SLOTracker acompanha SLOs declarados e publica as métricas de eventos bons e totais,
+// o burn rate em várias janelas e o error budget restante, todos com o atributo "slo":
+//
+//   - slo_events_total e slo_good_events_total (contadores)
+//   - slo_burn_rate (gauge, com o atributo "window")
+//   - slo_error_budget_remaining (gauge, fração do budget ainda disponível; negativo quando esgotado)
+//
+// Use MiddlewareConfig.SLOs para alimentar o tracker com as requisições dos middlewares HTTP,
+// ou Observe para eventos de outras fontes.
+type SLOTracker struct {
+	states       []*sloState
+	byName       map[string]*sloState
+	registration otelmetric.Registration
+	now          func() time.Time
+}
+
+// NewSLOTracker cria um tracker para os SLOs informados, registrando suas métricas em metrics,
+// que deve implementar InstrumentsHelper (como os helpers criados pelo graftel).
+func NewSLOTracker(metrics MetricsHelper, slos ...SLO) (*SLOTracker, error) {
+	instruments, ok := metrics.(InstrumentsHelper)
+	if !ok {
+		return nil, fmt.Errorf("NewSLOTracker: %T não implementa InstrumentsHelper", metrics)
+	}
+	t := &SLOTracker{byName: make(map[string]*sloState), now: time.Now}
+
+	total, err := metrics.NewCounter("slo_events_total", "Total de eventos avaliados pelo SLO")
+	if err != nil {
+		return nil, err
+	}
+	good, err := metrics.NewCounter("slo_good_events_total", "Eventos que atendem ao SLO")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slo := range slos {
+		if err := slo.validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := t.byName[slo.Name]; dup {
+			return nil, &ErrInvalidConfig{Field: "SLO.Name", Message: fmt.Sprintf("SLO '%s' duplicado", slo.Name)}
+		}
+		if slo.Window == 0 {
+			slo.Window = DefaultSLOWindow
+		}
+
+		state := &sloState{
+			slo:    slo,
+			good:   good.Bind(SLOKey.String(slo.Name)),
+			total:  total.Bind(SLOKey.String(slo.Name)),
+			burn:   newSLOWindow(time.Minute, sloBurnWindows[len(sloBurnWindows)-1]),
+			budget: newSLOWindow(time.Hour, slo.Window),
+		}
+		t.states = append(t.states, state)
+		t.byName[slo.Name] = state
+	}
+
+	burnRate, err := metrics.NewGauge("slo_burn_rate", "Taxa de consumo do error budget por janela", nil)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := metrics.NewGauge("slo_error_budget_remaining", "Fração do error budget restante na janela do SLO", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t.registration, err = instruments.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		now := t.now()
+		for _, s := range t.states {
+			name := SLOKey.String(s.slo.Name)
+			for _, window := range sloBurnWindows {
+				burnRate.Observe(o, s.burnRate(now, window), name, SLOWindowKey.String(promDuration(window)))
+			}
+			remaining.Observe(o, s.budgetRemaining(now), name)
+		}
+		return nil
+	}, burnRate, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ObserveRequest registra uma requisição HTTP em todos os SLOs que a incluem.
+// É seguro chamar em um tracker nil.
+func (t *SLOTracker) ObserveRequest(ctx context.Context, method, path string, status int, duration time.Duration) {
+	if t == nil {
+		return
+	}
+	now := t.now()
+	for _, s := range t.states {
+		if s.slo.matches(method, path) {
+			s.record(ctx, now, s.slo.good(status, duration))
+		}
+	}
+}
+
+// Observe registra um evento bom ou ruim no SLO informado, para SLOs que não
+// são alimentados pelos middlewares HTTP (ex: filas, jobs). É seguro chamar em um tracker nil.
+func (t *SLOTracker) Observe(ctx context.Context, name string, good bool) {
+	if t == nil {
+		return
+	}
+	s, ok := t.byName[name]
+	if !ok {
+		otel.Handle(fmt.Errorf("SLO '%s' não registrado no SLOTracker", name))
+		return
+	}
+	s.record(ctx, t.now(), good)
+}
+
+// BurnRate retorna a taxa de consumo do error budget do SLO na janela informada.
+// Uma taxa de 1 consome exatamente o budget ao fim da janela do SLO. As contagens têm
+// resolução de um minuto: window é arredondada para cima até minutos inteiros e limitada
+// a 3 dias, a maior janela de sloBurnWindows.
+func (t *SLOTracker) BurnRate(name string, window time.Duration) float64 {
+	if t == nil {
+		return 0
+	}
+	if s, ok := t.byName[name]; ok {
+		return s.burnRate(t.now(), window)
+	}
+	return 0
+}
+
+// ErrorBudgetRemaining retorna a fração do error budget ainda disponível na janela do SLO.
+func (t *SLOTracker) ErrorBudgetRemaining(name string) float64 {
+	if t == nil {
+		return 0
+	}
+	if s, ok := t.byName[name]; ok {
+		return s.budgetRemaining(t.now())
+	}
+	return 0
+}
+
+// PrometheusRules retorna as regras de recording e alerting dos SLOs do tracker.
+// Veja SLOPrometheusRules.
+func (t *SLOTracker) PrometheusRules() string {
+	if t == nil {
+		return ""
+	}
+	slos := make([]SLO, len(t.states))
+	for i, s := range t.states {
+		slos[i] = s.slo
+	}
+	rules, _ := SLOPrometheusRules(slos...)
+	return rules
+}
+
+// Close remove o callback das métricas de burn rate e error budget.
+func (t *SLOTracker) Close() error {
+	if t == nil {
+		return nil
+	}
+	return t.registration.Unregister()
+}
+
+// SLOPrometheusRules gera um arquivo de regras do Prometheus (YAML) com as mesmas contas
+// feitas pelo SLOTracker: a razão de erro de cada janela de burn rate e da janela do SLO como
+// recording rule (slo:sli_error:ratio_rate<janela>) e os alertas de burn rate multi-janela.
+// As regras usam as métricas slo_events_total e slo_good_events_total.
+func SLOPrometheusRules(slos ...SLO) (string, error) {
+	var b strings.Builder
+	b.WriteString("groups:\n")
+
+	for _, slo := range slos {
+		if err := slo.validate(); err != nil {
+			return "", err
+		}
+		if slo.Window == 0 {
+			slo.Window = DefaultSLOWindow
+		}
+		selector := fmt.Sprintf(`{slo=%q}`, slo.Name)
+		budget := strconv.FormatFloat(slo.errorBudget(), 'g', 10, 64)
+
+		fmt.Fprintf(&b, "  - name: slo-%s\n    rules:\n", slo.Name)
+		windows := sloBurnWindows
+		if !slices.Contains(windows, slo.Window) {
+			windows = append(slices.Clone(windows), slo.Window)
+		}
+		for _, window := range windows {
+			w := promDuration(window)
+			fmt.Fprintf(&b, "      - record: slo:sli_error:ratio_rate%s\n", w)
+			fmt.Fprintf(&b, "        expr: 1 - (sum(rate(slo_good_events_total%s[%s])) / sum(rate(slo_events_total%s[%s])))\n", selector, w, selector, w)
+			fmt.Fprintf(&b, "        labels:\n          slo: %q\n", slo.Name)
+		}
+
+		for _, alert := range sloAlerts {
+			long, short := promDuration(alert.long), promDuration(alert.short)
+			rate := strconv.FormatFloat(alert.burnRate, 'g', -1, 64)
+			b.WriteString("      - alert: SLOErrorBudgetBurn\n")
+			fmt.Fprintf(&b, "        expr: slo:sli_error:ratio_rate%s%s > (%s * %s) and slo:sli_error:ratio_rate%s%s > (%s * %s)\n",
+				long, selector, rate, budget, short, selector, rate, budget)
+			fmt.Fprintf(&b, "        labels:\n          severity: %s\n          slo: %q\n          long_window: %s\n", alert.severity, slo.Name, long)
+			fmt.Fprintf(&b, "        annotations:\n          summary: %q\n",
+				fmt.Sprintf("SLO %s consumindo o error budget %sx mais rápido que o sustentável (%s/%s)", slo.Name, rate, long, short))
+		}
+	}
+	return b.String(), nil
+}
+
+// promDuration formata a duração no formato do Prometheus (ex: 5m, 6h, 30d).
+func promDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
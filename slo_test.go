@@ -0,0 +1,232 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var checkoutSLO = SLO{
+	Name:             "checkout-latency",
+	Path:             "/checkout",
+	Objective:        0.99,
+	LatencyThreshold: 300 * time.Millisecond,
+}
+
+// newTestSLOTracker cria um tracker com relógio controlado pelo teste.
+func newTestSLOTracker(t *testing.T, slos ...SLO) (*SLOTracker, *metric.ManualReader, *time.Time) {
+	t.Helper()
+	reader := metric.NewManualReader()
+	helper := NewMetricsHelper(metric.NewMeterProvider(metric.WithReader(reader)).Meter("test"))
+
+	tracker, err := NewSLOTracker(helper, slos...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	return tracker, reader, &now
+}
+
+func assertFloat(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, esperado %v", name, got, want)
+	}
+}
+
+func TestSLOTracker_ObserveRequest(t *testing.T) {
+	tracker, reader, _ := newTestSLOTracker(t, checkoutSLO)
+	ctx := context.Background()
+
+	for i := 0; i < 96; i++ {
+		tracker.ObserveRequest(ctx, "POST", "/checkout", 200, 100*time.Millisecond)
+	}
+	tracker.ObserveRequest(ctx, "POST", "/checkout", 200, time.Second)          // lenta
+	tracker.ObserveRequest(ctx, "POST", "/checkout", 503, 10*time.Millisecond)  // erro
+	tracker.ObserveRequest(ctx, "POST", "/checkout", 404, 10*time.Millisecond)  // erro do cliente conta como bom
+	tracker.ObserveRequest(ctx, "GET", "/users", 500, 10*time.Millisecond)      // fora do SLO
+	tracker.ObserveRequest(ctx, "POST", "/checkout", 200, 300*time.Millisecond) // no limite
+
+	// 100 eventos, 2 ruins: razão de erro de 2% com budget de 1%
+	assertFloat(t, "BurnRate(5m)", tracker.BurnRate("checkout-latency", 5*time.Minute), 2)
+	assertFloat(t, "ErrorBudgetRemaining", tracker.ErrorBudgetRemaining("checkout-latency"), -1)
+
+	metrics := collectMetrics(t, reader)
+	total := metrics["slo_events_total"].Data.(metricdata.Sum[int64]).DataPoints[0]
+	good := metrics["slo_good_events_total"].Data.(metricdata.Sum[int64]).DataPoints[0]
+	if total.Value != 100 || good.Value != 98 {
+		t.Errorf("total = %d, bons = %d; esperado 100 e 98", total.Value, good.Value)
+	}
+	if v, _ := total.Attributes.Value(SLOKey); v.AsString() != "checkout-latency" {
+		t.Errorf("atributo slo = %q", v.AsString())
+	}
+
+	burn := metrics["slo_burn_rate"].Data.(metricdata.Gauge[float64])
+	if len(burn.DataPoints) != len(sloBurnWindows) {
+		t.Errorf("esperado um ponto por janela, obtidos %d", len(burn.DataPoints))
+	}
+	remaining := metrics["slo_error_budget_remaining"].Data.(metricdata.Gauge[float64])
+	assertFloat(t, "slo_error_budget_remaining", remaining.DataPoints[0].Value, -1)
+}
+
+func TestSLOTracker_BurnRateWindows(t *testing.T) {
+	tracker, _, now := newTestSLOTracker(t, SLO{Name: "api", Objective: 0.9})
+	ctx := context.Background()
+
+	tracker.ObserveRequest(ctx, "GET", "/", 500, 0)
+	tracker.ObserveRequest(ctx, "GET", "/", 200, 0)
+
+	// Janelas menores que a resolução de um minuto incluem o minuto atual
+	assertFloat(t, "BurnRate(30s)", tracker.BurnRate("api", 30*time.Second), 0.5/0.1)
+
+	*now = now.Add(10 * time.Minute)
+	tracker.ObserveRequest(ctx, "GET", "/", 200, 0)
+	tracker.ObserveRequest(ctx, "GET", "/", 200, 0)
+
+	// Na janela de 5m só há eventos bons; na de 1h, 1 ruim em 4
+	assertFloat(t, "BurnRate(5m)", tracker.BurnRate("api", 5*time.Minute), 0)
+	assertFloat(t, "BurnRate(1h)", tracker.BurnRate("api", time.Hour), 0.25/0.1)
+	assertFloat(t, "BurnRate(90m)", tracker.BurnRate("api", 90*time.Minute), 0.25/0.1)
+
+	*now = now.Add(4 * 24 * time.Hour)
+	assertFloat(t, "BurnRate(3d) após 4 dias", tracker.BurnRate("api", 72*time.Hour), 0)
+	assertFloat(t, "ErrorBudgetRemaining após 4 dias", tracker.ErrorBudgetRemaining("api"), 1-0.25/0.1)
+}
+
+func TestSLOTracker_Observe(t *testing.T) {
+	errs := captureErrors(t)
+	tracker, _, _ := newTestSLOTracker(t, SLO{Name: "jobs", Objective: 0.5})
+
+	tracker.Observe(context.Background(), "jobs", true)
+	tracker.Observe(context.Background(), "jobs", false)
+	tracker.Observe(context.Background(), "desconhecido", false)
+
+	assertFloat(t, "BurnRate", tracker.BurnRate("jobs", time.Hour), 1)
+	if len(errs()) != 1 {
+		t.Error("esperado erro ao observar SLO não registrado")
+	}
+}
+
+func TestSLOTracker_NilIsSafe(t *testing.T) {
+	var tracker *SLOTracker
+	ctx := context.Background()
+
+	tracker.Observe(ctx, "jobs", true)
+	tracker.ObserveRequest(ctx, "GET", "/", 200, time.Millisecond)
+	if tracker.BurnRate("jobs", time.Hour) != 0 || tracker.ErrorBudgetRemaining("jobs") != 0 {
+		t.Error("tracker nil deveria retornar 0")
+	}
+	if tracker.PrometheusRules() != "" || tracker.Close() != nil {
+		t.Error("tracker nil deveria retornar valores zero")
+	}
+}
+
+func TestNewSLOTracker_Validation(t *testing.T) {
+	helper := NewMetricsHelper(metric.NewMeterProvider().Meter("test"))
+
+	tests := []struct {
+		name  string
+		slos  []SLO
+		field string
+	}{
+		{"sem nome", []SLO{{Objective: 0.99}}, "SLO.Name"},
+		{"objetivo 1", []SLO{{Name: "a", Objective: 1}}, "SLO[a].Objective"},
+		{"objetivo zero", []SLO{{Name: "a"}}, "SLO[a].Objective"},
+		{"latência negativa", []SLO{{Name: "a", Objective: 0.9, LatencyThreshold: -1}}, "SLO[a].LatencyThreshold"},
+		{"duplicado", []SLO{{Name: "a", Objective: 0.9}, {Name: "a", Objective: 0.99}}, "SLO.Name"},
+		{"janela negativa", []SLO{{Name: "a", Objective: 0.9, Window: -time.Hour}}, "SLO[a].Window"},
+		{"janela menor que 1h", []SLO{{Name: "a", Objective: 0.9, Window: 30 * time.Minute}}, "SLO[a].Window"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSLOTracker(helper, tt.slos...)
+			var invalid *ErrInvalidConfig
+			if !errors.As(err, &invalid) || invalid.Field != tt.field {
+				t.Errorf("esperado ErrInvalidConfig em %s, obtido %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestNewSLOTracker_RequiresInstrumentsHelper(t *testing.T) {
+	// Um MetricsHelper próprio não tem RegisterCallback
+	metrics := struct{ MetricsHelper }{NewMetricsHelper(metric.NewMeterProvider().Meter("test"))}
+	if _, err := NewSLOTracker(metrics, SLO{Name: "api", Objective: 0.9}); err == nil {
+		t.Error("esperado erro para um MetricsHelper sem InstrumentsHelper")
+	}
+}
+
+func TestSLOPrometheusRules(t *testing.T) {
+	rules, err := SLOPrometheusRules(checkoutSLO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"  - name: slo-checkout-latency\n",
+		`      - record: slo:sli_error:ratio_rate5m`,
+		`expr: 1 - (sum(rate(slo_good_events_total{slo="checkout-latency"}[30d])) / sum(rate(slo_events_total{slo="checkout-latency"}[30d])))`,
+		`expr: slo:sli_error:ratio_rate1h{slo="checkout-latency"} > (14.4 * 0.01) and slo:sli_error:ratio_rate5m{slo="checkout-latency"} > (14.4 * 0.01)`,
+		`          severity: ticket`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(rules, want) {
+			t.Errorf("regras não contêm %q:\n%s", want, rules)
+		}
+	}
+	if n := strings.Count(rules, "- alert: SLOErrorBudgetBurn"); n != len(sloAlerts) {
+		t.Errorf("esperados %d alertas, obtidos %d", len(sloAlerts), n)
+	}
+
+	if _, err := SLOPrometheusRules(SLO{Name: "x"}); err == nil {
+		t.Error("esperado erro para SLO inválido")
+	}
+}
+
+func TestHTTPMiddleware_FeedsSLOTracker(t *testing.T) {
+	client := createTestClientForMiddleware()
+	defer client.Shutdown(context.Background())
+
+	tracker, err := NewSLOTracker(client.NewMetricsHelper("slo"), SLO{Name: "api", Path: "/api", Objective: 0.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tracker.Close()
+
+	config := DefaultMiddlewareConfig("test-service")
+	config.SLOs = tracker
+	handler := HTTPMiddleware(client, config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api", nil))
+
+	assertFloat(t, "BurnRate", tracker.BurnRate("api", time.Hour), 10)
+}
+
+func TestPromDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		5 * time.Minute:  "5m",
+		6 * time.Hour:    "6h",
+		72 * time.Hour:   "3d",
+		DefaultSLOWindow: "30d",
+		90 * time.Second: "90s",
+		90 * time.Minute: "90m",
+		24 * time.Hour:   "1d",
+	}
+	for d, want := range tests {
+		if got := promDuration(d); got != want {
+			t.Errorf("promDuration(%v) = %q, esperado %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package graftel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// SlogHandlerOptions configura o handler criado por NewSlogHandler.
+type SlogHandlerOptions struct {
+	// Level é o nível mínimo dos registros tratados. Padrão: slog.LevelInfo.
+	Level slog.Leveler
+
+	// AddSource adiciona code.filepath, code.lineno e code.function a cada registro.
+	AddSource bool
+}
+
+// slogGroup é um grupo aberto por WithGroup e os atributos adicionados dentro dele.
+type slogGroup struct {
+	name  string
+	attrs []otellog.KeyValue
+}
+
+// slogHandler implementa slog.Handler emitindo os registros pelo LogsHelper do Client.
+type slogHandler struct {
+	helper *logsHelper
+	opts   SlogHandlerOptions
+
+	// attrs são os atributos de WithAttrs fora de qualquer grupo, já convertidos.
+	attrs  []otellog.KeyValue
+	groups []slogGroup
+}
+
+// NewSlogHandler cria um slog.Handler que envia os registros pelo Logger name do client,
+// com o mesmo formato do LogsHelper: atributos prefixados com "tags.", tags no body e
+// impressão no console. Grupos viram atributos aninhados, e as tags de WithTags e o
+// trace/span do ctx são incluídos em cada registro. opts pode ser nil.
+//
+//	slog.SetDefault(slog.New(graftel.NewSlogHandler(client, "meu-servico", nil)))
+func NewSlogHandler(client Client, name string, opts *SlogHandlerOptions) slog.Handler {
+	helper, ok := client.NewLogsHelper(name).(*logsHelper)
+	if !ok {
+		helper = &logsHelper{logger: client.GetLogger(name), name: name}
+	}
+	h := &slogHandler{helper: helper}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	return h
+}
+
+// Enabled implementa slog.Handler.
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if level < h.opts.Level.Level() {
+		return false
+	}
+	logLevel, _ := slogLevel(level)
+	return LogEnabled(ctx, h.helper, logLevel)
+}
+
+// Handle implementa slog.Handler.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	level, severity := slogLevel(r.Level)
+	if !LogEnabled(ctx, h.helper, level) {
+		return nil
+	}
+
+	// Atributos do registro entram no grupo mais interno, que é aninhado nos anteriores
+	attrs := make([]otellog.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendSlogAttr(attrs, a)
+		return true
+	})
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		g := h.groups[i]
+		inner := append(append(make([]otellog.KeyValue, 0, len(g.attrs)+len(attrs)), g.attrs...), attrs...)
+		if len(inner) == 0 {
+			// Grupos vazios são omitidos, como nos handlers do slog
+			attrs = nil
+			continue
+		}
+		attrs = []otellog.KeyValue{otellog.Map(g.name, inner...)}
+	}
+
+	ctxTags := GetTagsFromContext(ctx)
+	all := make([]otellog.KeyValue, 0, len(h.attrs)+len(attrs)+len(ctxTags))
+	all = append(all, h.attrs...)
+	all = append(all, attrs...)
+	all = prefixLogAttributes(all)
+	if len(ctxTags) > 0 {
+		all = append(all, convertAttributes(ctxTags)...)
+	}
+
+	record := otellog.Record{}
+	record.SetTimestamp(r.Time)
+	record.SetSeverity(severity)
+	record.SetSeverityText(r.Level.String())
+	record.AddAttributes(all...)
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		record.AddAttributes(
+			otellog.String(string(semconv.CodeFilepathKey), frame.File),
+			otellog.Int(string(semconv.CodeLineNumberKey), frame.Line),
+			otellog.String(string(semconv.CodeFunctionKey), frame.Function),
+		)
+	}
+
+	h.helper.emit(ctx, level, record, r.Message, formatLogAttributes(all))
+	return nil
+}
+
+// WithAttrs implementa slog.Handler. Os atributos são convertidos uma única vez.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	converted := make([]otellog.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		converted = appendSlogAttr(converted, a)
+	}
+
+	clone := *h
+	if len(h.groups) == 0 {
+		clone.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], converted...)
+		return &clone
+	}
+	clone.groups = append([]slogGroup(nil), h.groups...)
+	last := &clone.groups[len(clone.groups)-1]
+	last.attrs = append(last.attrs[:len(last.attrs):len(last.attrs)], converted...)
+	return &clone
+}
+
+// WithGroup implementa slog.Handler.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(h.groups[:len(h.groups):len(h.groups)], slogGroup{name: name})
+	return &clone
+}
+
+// slogLevel converte um nível do slog no LogLevel e na severidade OpenTelemetry.
+// Níveis intermediários (ex: slog.LevelWarn+2) usam as severidades numeradas
+// (WARN3), e níveis acima de slog.LevelError+4 viram FATAL.
+func slogLevel(level slog.Level) (LogLevel, otellog.Severity) {
+	severity := otellog.Severity(min(max(int(level)+9, int(otellog.SeverityTrace1)), int(otellog.SeverityFatal4)))
+
+	switch {
+	case level < slog.LevelDebug:
+		return LogLevelTrace, severity
+	case level < slog.LevelInfo:
+		return LogLevelDebug, severity
+	case level < slog.LevelWarn:
+		return LogLevelInfo, severity
+	case level < slog.LevelError:
+		return LogLevelWarn, severity
+	case level < slog.LevelError+4:
+		return LogLevelError, severity
+	default:
+		return LogLevelFatal, severity
+	}
+}
+
+// appendSlogAttr converte um atributo do slog. Atributos vazios são ignorados e grupos
+// sem chave são expandidos no nível atual.
+func appendSlogAttr(kvs []otellog.KeyValue, a slog.Attr) []otellog.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		var members []otellog.KeyValue
+		for _, member := range a.Value.Group() {
+			members = appendSlogAttr(members, member)
+		}
+		if len(members) == 0 {
+			return kvs
+		}
+		if a.Key == "" {
+			return append(kvs, members...)
+		}
+		return append(kvs, otellog.Map(a.Key, members...))
+	}
+	return append(kvs, otellog.KeyValue{Key: a.Key, Value: slogValue(a.Value)})
+}
+
+// slogValue converte um valor do slog já resolvido.
+func slogValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return otellog.Int64Value(int64(u))
+		}
+		return otellog.StringValue(v.String())
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.StringValue(v.Duration().String())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	default:
+		switch val := v.Any().(type) {
+		case error:
+			return otellog.StringValue(val.Error())
+		case []byte:
+			return otellog.BytesValue(val)
+		case fmt.Stringer:
+			return otellog.StringValue(val.String())
+		default:
+			return otellog.StringValue(fmt.Sprintf("%+v", val))
+		}
+	}
+}
+
+// prefixLogAttributes prefixa com "tags." as chaves do primeiro nível, como convertAttributes.
+func prefixLogAttributes(kvs []otellog.KeyValue) []otellog.KeyValue {
+	for i, kv := range kvs {
+		if !strings.HasPrefix(kv.Key, "tags.") {
+			kvs[i].Key = "tags." + kv.Key
+		}
+	}
+	return kvs
+}
+
+// formatLogAttributes formata os atributos como formatTags, achatando os grupos
+// aninhados em chaves com ponto (ex: [request.id:42]).
+func formatLogAttributes(kvs []otellog.KeyValue) string {
+	var b strings.Builder
+	var walk func(prefix string, kvs []otellog.KeyValue)
+	walk = func(prefix string, kvs []otellog.KeyValue) {
+		for _, kv := range kvs {
+			key := prefix + strings.TrimPrefix(kv.Key, "tags.")
+			if kv.Value.Kind() == otellog.KindMap {
+				walk(key+".", kv.Value.AsMap())
+				continue
+			}
+			fmt.Fprintf(&b, "[%s:%s]", key, kv.Value.String())
+		}
+	}
+	walk("", kvs)
+	return b.String()
+}
@@ -0,0 +1,212 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// logRecorder é um sdklog.Processor que guarda os registros emitidos.
+type logRecorder struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (r *logRecorder) OnEmit(_ context.Context, record *sdklog.Record) error {
+	r.mu.Lock()
+	r.records = append(r.records, record.Clone())
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *logRecorder) Shutdown(context.Context) error   { return nil }
+func (r *logRecorder) ForceFlush(context.Context) error { return nil }
+
+func (r *logRecorder) all() []sdklog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]sdklog.Record(nil), r.records...)
+}
+
+// newRecordingClient cria um Client cujos logs são guardados no logRecorder retornado.
+func newRecordingClient(t *testing.T) (Client, *logRecorder) {
+	t.Helper()
+	recorder := &logRecorder{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return &client{loggerProvider: provider}, recorder
+}
+
+// recordAttributes retorna os atributos de um registro indexados pela chave.
+func recordAttributes(record sdklog.Record) map[string]otellog.Value {
+	attrs := make(map[string]otellog.Value)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func mapValue(v otellog.Value) map[string]otellog.Value {
+	m := make(map[string]otellog.Value)
+	for _, kv := range v.AsMap() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestSlogHandler(t *testing.T) {
+	client, recorder := newRecordingClient(t)
+	logger := slog.New(NewSlogHandler(client, "test", nil))
+
+	ctx := WithTags(context.Background(), attribute.String("tenant", "acme"))
+	logger.InfoContext(ctx, "pedido criado", "order_id", 42, slog.Group("customer", "id", "c-1", "vip", true))
+	logger.Debug("ignorado pelo nível padrão")
+
+	records := recorder.all()
+	if len(records) != 1 {
+		t.Fatalf("esperado 1 registro, obtidos %d", len(records))
+	}
+	record := records[0]
+
+	if record.Severity() != otellog.SeverityInfo || record.SeverityText() != "INFO" {
+		t.Errorf("severidade = %v %q", record.Severity(), record.SeverityText())
+	}
+	if body := record.Body().AsString(); body != "pedido criado [order_id:42][customer.id:c-1][customer.vip:true][tenant:acme]" {
+		t.Errorf("body = %q", body)
+	}
+	if record.Timestamp().IsZero() {
+		t.Error("timestamp do slog.Record deveria ser preservado")
+	}
+
+	attrs := recordAttributes(record)
+	if attrs["tags.order_id"].AsInt64() != 42 || attrs["tags.tenant"].AsString() != "acme" {
+		t.Errorf("atributos = %v", attrs)
+	}
+	customer := mapValue(attrs["tags.customer"])
+	if customer["id"].AsString() != "c-1" || !customer["vip"].AsBool() {
+		t.Errorf("grupo customer = %v", customer)
+	}
+}
+
+func TestSlogHandler_WithAttrsAndGroups(t *testing.T) {
+	client, recorder := newRecordingClient(t)
+	logger := slog.New(NewSlogHandler(client, "test", nil)).
+		With("service", "api").
+		WithGroup("http").
+		With("method", "GET").
+		WithGroup("response")
+
+	logger.Info("requisição", "status", 200)
+	logger.WithGroup("vazio").Info("sem atributos")
+
+	records := recorder.all()
+	if len(records) != 2 {
+		t.Fatalf("esperados 2 registros, obtidos %d", len(records))
+	}
+
+	attrs := recordAttributes(records[0])
+	if attrs["tags.service"].AsString() != "api" {
+		t.Errorf("service = %v", attrs["tags.service"])
+	}
+	httpGroup := mapValue(attrs["tags.http"])
+	if httpGroup["method"].AsString() != "GET" {
+		t.Errorf("http.method = %v", httpGroup["method"])
+	}
+	if response := mapValue(httpGroup["response"]); response["status"].AsInt64() != 200 {
+		t.Errorf("http.response.status = %v", response["status"])
+	}
+
+	// Grupos sem atributos no registro são omitidos, mantendo os atributos dos grupos externos
+	attrs = recordAttributes(records[1])
+	if _, ok := mapValue(attrs["tags.http"])["response"]; ok {
+		t.Error("grupos vazios deveriam ser omitidos")
+	}
+	if mapValue(attrs["tags.http"])["method"].AsString() != "GET" {
+		t.Errorf("http.method deveria ser mantido: %v", attrs)
+	}
+}
+
+func TestSlogHandler_WithAttrsDoesNotShareState(t *testing.T) {
+	client, recorder := newRecordingClient(t)
+	base := slog.New(NewSlogHandler(client, "test", nil)).With("a", 1)
+
+	base.With("b", 2).Info("primeiro")
+	base.With("c", 3).Info("segundo")
+
+	attrs := recordAttributes(recorder.all()[1])
+	if _, ok := attrs["tags.b"]; ok {
+		t.Errorf("atributos de um logger derivado vazaram para outro: %v", attrs)
+	}
+}
+
+func TestSlogHandler_TraceContext(t *testing.T) {
+	client, recorder := newRecordingClient(t)
+	logger := slog.New(NewSlogHandler(client, "test", &SlogHandlerOptions{Level: slog.LevelDebug, AddSource: true}))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	logger.DebugContext(ctx, "dentro do span", "err", errors.New("falhou"), "elapsed", 1500*time.Millisecond)
+	span.End()
+
+	record := recorder.all()[0]
+	if record.TraceID() != span.SpanContext().TraceID() || record.SpanID() != span.SpanContext().SpanID() {
+		t.Error("registro deveria ter o trace_id e span_id do ctx")
+	}
+
+	attrs := recordAttributes(record)
+	if attrs["tags.err"].AsString() != "falhou" || attrs["tags.elapsed"].AsString() != "1.5s" {
+		t.Errorf("atributos = %v", attrs)
+	}
+	if !strings.HasSuffix(attrs["code.filepath"].AsString(), "slog_test.go") || attrs["code.lineno"].AsInt64() == 0 {
+		t.Errorf("AddSource deveria adicionar code.*: %v", attrs)
+	}
+}
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		want     LogLevel
+		severity otellog.Severity
+	}{
+		{slog.LevelDebug - 4, LogLevelTrace, otellog.SeverityTrace1},
+		{slog.LevelDebug, LogLevelDebug, otellog.SeverityDebug},
+		{slog.LevelInfo, LogLevelInfo, otellog.SeverityInfo},
+		{slog.LevelWarn + 2, LogLevelWarn, otellog.SeverityWarn3},
+		{slog.LevelError, LogLevelError, otellog.SeverityError},
+		{slog.LevelError + 4, LogLevelFatal, otellog.SeverityFatal},
+		{slog.LevelError + 100, LogLevelFatal, otellog.SeverityFatal4},
+		{slog.LevelDebug - 100, LogLevelTrace, otellog.SeverityTrace1},
+	}
+	for _, tt := range tests {
+		level, severity := slogLevel(tt.level)
+		if level != tt.want || severity != tt.severity {
+			t.Errorf("slogLevel(%v) = (%v, %v), esperado (%v, %v)", tt.level, level, severity, tt.want, tt.severity)
+		}
+	}
+}
+
+func TestSlogHandler_LoggerLevel(t *testing.T) {
+	c, recorder := newRecordingClient(t)
+	c.(*client).config = NewConfig("test").WithLoggerLevel("billing", LogLevelWarn)
+	handler := NewSlogHandler(c, "billing/http", &SlogHandlerOptions{Level: slog.LevelDebug})
+
+	if handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled deveria respeitar o nível do logger no Client")
+	}
+	logger := slog.New(handler)
+	logger.Info("descartado")
+	logger.Warn("emitido")
+
+	if records := recorder.all(); len(records) != 1 || records[0].Severity() != otellog.SeverityWarn {
+		t.Errorf("esperado apenas o log WARN, obtidos %d registros", len(records))
+	}
+}
@@ -0,0 +1,383 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// DefaultStatsDMaxPacketSize é o tamanho máximo padrão de um datagrama StatsD,
+// que cabe em um pacote UDP sobre Ethernet sem fragmentação.
+const DefaultStatsDMaxPacketSize = 1432
+
+// StatsDFlavor define o dialeto das linhas enviadas ao agente.
+type StatsDFlavor int
+
+const (
+	// StatsDFlavorDogStatsD envia os atributos como tags (|#chave:valor). É o padrão.
+	StatsDFlavorDogStatsD StatsDFlavor = iota
+	// StatsDFlavorStatsD envia linhas StatsD puras; os atributos são descartados.
+	StatsDFlavorStatsD
+)
+
+// String retorna a representação em string do dialeto, no formato de GRAFTEL_STATSD_FLAVOR.
+func (f StatsDFlavor) String() string {
+	switch f {
+	case StatsDFlavorDogStatsD:
+		return "dogstatsd"
+	case StatsDFlavorStatsD:
+		return "statsd"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseStatsDFlavor converte "dogstatsd" ou "statsd" em StatsDFlavor.
+func ParseStatsDFlavor(s string) (StatsDFlavor, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "dogstatsd":
+		return StatsDFlavorDogStatsD, nil
+	case "statsd":
+		return StatsDFlavorStatsD, nil
+	default:
+		return StatsDFlavorDogStatsD, fmt.Errorf("dialeto StatsD inválido: %q", s)
+	}
+}
+
+// StatsDConfig configura a exportação de métricas para um agente StatsD/DogStatsD via UDP.
+type StatsDConfig struct {
+	// Endpoint é o endereço UDP do agente (ex: localhost:8125). Vazio desabilita o StatsD.
+	Endpoint string
+
+	// Flavor é o dialeto das linhas. Padrão: StatsDFlavorDogStatsD.
+	Flavor StatsDFlavor
+
+	// Prefix é adicionado ao nome de todas as métricas (ex: "meu_servico.").
+	Prefix string
+
+	// MaxPacketSize é o tamanho máximo de cada datagrama. Linhas são agrupadas até
+	// esse limite. Padrão: DefaultStatsDMaxPacketSize.
+	MaxPacketSize int
+}
+
+// loadFromEnv carrega os campos vazios de GRAFTEL_STATSD_ENDPOINT, GRAFTEL_STATSD_FLAVOR,
+// GRAFTEL_STATSD_PREFIX e GRAFTEL_STATSD_MAX_PACKET_SIZE.
+func (s *StatsDConfig) loadFromEnv() {
+	if s.Endpoint == "" {
+		s.Endpoint = os.Getenv("GRAFTEL_STATSD_ENDPOINT")
+	}
+
+	if s.Flavor == StatsDFlavorDogStatsD {
+		if val := os.Getenv("GRAFTEL_STATSD_FLAVOR"); val != "" {
+			if flavor, err := ParseStatsDFlavor(val); err == nil {
+				s.Flavor = flavor
+			}
+		}
+	}
+
+	if s.Prefix == "" {
+		s.Prefix = os.Getenv("GRAFTEL_STATSD_PREFIX")
+	}
+
+	if s.MaxPacketSize == 0 {
+		if val := os.Getenv("GRAFTEL_STATSD_MAX_PACKET_SIZE"); val != "" {
+			if size, err := strconv.Atoi(val); err == nil {
+				s.MaxPacketSize = size
+			}
+		}
+	}
+}
+
+// validate verifica a configuração e aplica o tamanho de pacote padrão.
+func (s *StatsDConfig) validate() error {
+	if s.Flavor < StatsDFlavorDogStatsD || s.Flavor > StatsDFlavorStatsD {
+		return &ErrInvalidConfig{Field: "StatsD.Flavor", Message: "valor desconhecido"}
+	}
+	if s.MaxPacketSize < 0 {
+		return &ErrInvalidConfig{Field: "StatsD.MaxPacketSize", Message: "não pode ser negativo"}
+	}
+	if s.MaxPacketSize == 0 {
+		s.MaxPacketSize = DefaultStatsDMaxPacketSize
+	}
+	return nil
+}
+
+// WithStatsD exporta as métricas para um agente StatsD/DogStatsD em vez de OTLP.
+func (c Config) WithStatsD(statsd StatsDConfig) Config {
+	c.StatsD = statsd
+	return c
+}
+
+// statsdExporter traduz as métricas coletadas em linhas StatsD e as envia via UDP.
+type statsdExporter struct {
+	config StatsDConfig
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewStatsDExporter cria um sdkmetric.Exporter que envia as métricas para um agente
+// StatsD/DogStatsD. Use com sdkmetric.NewPeriodicReader.
+//
+// Contadores são enviados como deltas (|c), up-down counters e gauges como gauges (|g)
+// e histogramas como timers (|ms, para unidades "s" e "ms") ou histogramas (|h): cada
+// bucket com amostras gera uma linha com um valor representativo do bucket e taxa de
+// amostragem 1/contagem, para que o agente reconstrua a contagem e a distribuição.
+// Histogramas exponenciais, summaries e métricas cumulativas de producers (como a ponte
+// com o Prometheus) não são suportados e são ignorados.
+func NewStatsDExporter(config StatsDConfig) (sdkmetric.Exporter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	conn, err := net.Dial("udp", config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao conectar ao agente StatsD: %w", err)
+	}
+	return &statsdExporter{config: config, conn: conn}, nil
+}
+
+// Temporality usa delta para contadores e histogramas, como esperado pelo StatsD,
+// e cumulativa para up-down counters, enviados como gauges.
+func (e *statsdExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+		return metricdata.CumulativeTemporality
+	default:
+		return metricdata.DeltaTemporality
+	}
+}
+
+// Aggregation usa a agregação padrão do SDK.
+func (e *statsdExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export envia as métricas em datagramas de até MaxPacketSize bytes.
+func (e *statsdExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	var lines []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			lines = e.appendMetric(lines, m)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return errors.New("exporter StatsD encerrado")
+	}
+
+	var errs []error
+	for _, packet := range statsdPackets(lines, e.config.MaxPacketSize) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := e.conn.Write(packet); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ForceFlush não faz nada: os datagramas são enviados em Export.
+func (e *statsdExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown fecha a conexão UDP.
+func (e *statsdExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// appendMetric converte uma métrica em linhas StatsD.
+func (e *statsdExporter) appendMetric(lines []string, m metricdata.Metrics) []string {
+	name := e.config.Prefix + statsdName(m.Name)
+
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		return appendSum(lines, e, name, data.IsMonotonic, data.Temporality, data.DataPoints)
+	case metricdata.Sum[float64]:
+		return appendSum(lines, e, name, data.IsMonotonic, data.Temporality, data.DataPoints)
+	case metricdata.Gauge[int64]:
+		return appendGauge(lines, e, name, data.DataPoints)
+	case metricdata.Gauge[float64]:
+		return appendGauge(lines, e, name, data.DataPoints)
+	case metricdata.Histogram[int64]:
+		if data.Temporality == metricdata.DeltaTemporality {
+			return appendHistogram(lines, e, name, m.Unit, data.DataPoints)
+		}
+	case metricdata.Histogram[float64]:
+		if data.Temporality == metricdata.DeltaTemporality {
+			return appendHistogram(lines, e, name, m.Unit, data.DataPoints)
+		}
+	}
+	return lines
+}
+
+func appendSum[N int64 | float64](lines []string, e *statsdExporter, name string, monotonic bool, temporality metricdata.Temporality, points []metricdata.DataPoint[N]) []string {
+	if monotonic && temporality != metricdata.DeltaTemporality {
+		return lines
+	}
+	for _, dp := range points {
+		if !monotonic {
+			lines = append(lines, e.gaugeLine(name, float64(dp.Value), dp.Attributes))
+		} else if dp.Value != 0 {
+			lines = append(lines, e.line(name, float64(dp.Value), "c", 1, dp.Attributes))
+		}
+	}
+	return lines
+}
+
+func appendGauge[N int64 | float64](lines []string, e *statsdExporter, name string, points []metricdata.DataPoint[N]) []string {
+	for _, dp := range points {
+		lines = append(lines, e.gaugeLine(name, float64(dp.Value), dp.Attributes))
+	}
+	return lines
+}
+
+// gaugeLine formata a linha de um gauge. No StatsD puro, um valor com sinal é um
+// incremento relativo (ex: -5 subtrai 5); para enviar um valor negativo absoluto o
+// gauge é zerado antes, na mesma linha para que ambos fiquem no mesmo pacote.
+func (e *statsdExporter) gaugeLine(name string, value float64, attrs attribute.Set) string {
+	line := e.line(name, value, "g", 1, attrs)
+	if value < 0 && e.config.Flavor == StatsDFlavorStatsD {
+		return e.line(name, 0, "g", 1, attrs) + "\n" + line
+	}
+	return line
+}
+
+func appendHistogram[N int64 | float64](lines []string, e *statsdExporter, name, unit string, points []metricdata.HistogramDataPoint[N]) []string {
+	metricType, scale := "h", 1.0
+	switch unit {
+	case "s":
+		metricType, scale = "ms", 1000
+	case "ms":
+		metricType = "ms"
+	}
+
+	for _, dp := range points {
+		minValue, hasMin := dp.Min.Value()
+		maxValue, hasMax := dp.Max.Value()
+		for i, count := range dp.BucketCounts {
+			if count == 0 {
+				continue
+			}
+			value := bucketValue(dp.Bounds, i, float64(minValue), hasMin, float64(maxValue), hasMax)
+			lines = append(lines, e.line(name, value*scale, metricType, 1/float64(count), dp.Attributes))
+		}
+	}
+	return lines
+}
+
+// bucketValue retorna o valor representativo do bucket i: o ponto médio dos limites,
+// restringido ao mínimo e ao máximo observados quando disponíveis.
+func bucketValue(bounds []float64, i int, minValue float64, hasMin bool, maxValue float64, hasMax bool) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		lower = bounds[i-1]
+	}
+	if i < len(bounds) {
+		upper = bounds[i]
+	}
+	if hasMin && minValue > lower {
+		lower = minValue
+	}
+	if hasMax && maxValue < upper {
+		upper = maxValue
+	}
+
+	switch {
+	case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+		return 0
+	case math.IsInf(lower, -1):
+		return upper
+	case math.IsInf(upper, 1):
+		return lower
+	default:
+		return (lower + upper) / 2
+	}
+}
+
+// line formata uma linha StatsD: nome:valor|tipo[|@taxa][|#tags].
+func (e *statsdExporter) line(name string, value float64, metricType string, rate float64, attrs attribute.Set) string {
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(statsdValue(value))
+	b.WriteByte('|')
+	b.WriteString(metricType)
+	if rate < 1 {
+		b.WriteString("|@")
+		b.WriteString(strconv.FormatFloat(rate, 'g', 6, 64))
+	}
+
+	if e.config.Flavor == StatsDFlavorDogStatsD && attrs.Len() > 0 {
+		b.WriteString("|#")
+		iter := attrs.Iter()
+		for iter.Next() {
+			i, kv := iter.IndexedAttribute()
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(statsdTagKey(string(kv.Key)))
+			b.WriteByte(':')
+			b.WriteString(statsdTag(kv.Value.Emit()))
+		}
+	}
+	return b.String()
+}
+
+// statsdValue formata o valor em notação decimal com até 6 casas, sem o ruído de
+// ponto flutuante das conversões de unidade (ex: 0.3 s -> 300 ms).
+func statsdValue(value float64) string {
+	s := strconv.FormatFloat(value, 'f', 6, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// statsdPackets agrupa as linhas em datagramas de até maxSize bytes, separadas por "\n".
+// Uma linha maior que maxSize é enviada sozinha.
+func statsdPackets(lines []string, maxSize int) [][]byte {
+	var packets [][]byte
+	var current []byte
+	for _, line := range lines {
+		if len(current) > 0 && len(current)+1+len(line) > maxSize {
+			packets = append(packets, current)
+			current = nil
+		}
+		if len(current) > 0 {
+			current = append(current, '\n')
+		}
+		current = append(current, line...)
+	}
+	if len(current) > 0 {
+		packets = append(packets, current)
+	}
+	return packets
+}
+
+// statsdName substitui os caracteres reservados do protocolo em nomes de métricas.
+var statsdName = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", "\n", "_", " ", "_").Replace
+
+// statsdTag substitui os caracteres reservados do protocolo em valores de tags.
+var statsdTag = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_").Replace
+
+// statsdTagKey também substitui ":", que separa a chave do valor da tag.
+var statsdTagKey = strings.NewReplacer(":", "_", ",", "_", "|", "_", "#", "_", "\n", "_").Replace
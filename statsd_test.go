@@ -0,0 +1,244 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// listenStatsD abre um listener UDP local e retorna seu endereço e uma função que
+// lê os datagramas recebidos até o timeout.
+func listenStatsD(t *testing.T) (string, func() []string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn.LocalAddr().String(), func() []string {
+		var packets []string
+		buf := make([]byte, 65535)
+		for {
+			conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return packets
+			}
+			packets = append(packets, string(buf[:n]))
+		}
+	}
+}
+
+// statsdLines separa os datagramas em linhas.
+func statsdLines(packets []string) []string {
+	var lines []string
+	for _, p := range packets {
+		lines = append(lines, strings.Split(p, "\n")...)
+	}
+	return lines
+}
+
+// newStatsDReader cria um PeriodicReader que exporta para config apenas no ForceFlush.
+func newStatsDReader(t *testing.T, config StatsDConfig) *metric.PeriodicReader {
+	t.Helper()
+	exporter, err := NewStatsDExporter(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metric.NewPeriodicReader(exporter, metric.WithInterval(time.Hour))
+}
+
+func TestStatsDExporter_DogStatsD(t *testing.T) {
+	addr, read := listenStatsD(t)
+	reader := newStatsDReader(t, StatsDConfig{Endpoint: addr, Prefix: "app."})
+	helper, _ := newTestMetricsHelper(t, metric.WithReader(reader))
+	ctx := context.Background()
+
+	requests, _ := helper.NewCounter("requests_total", "Requisições")
+	inFlight, _ := helper.NewUpDownCounter("in_flight", "Em andamento")
+	temperature, _ := helper.NewFloat64Gauge("temperature", "Temperatura")
+	latency, _ := helper.NewHistogram("latency", "Latência", WithBuckets(0.1, 1), otelmetric.WithUnit("s"))
+
+	requests.Add(ctx, 3, attribute.String("method", "GET"), attribute.Int("status", 200))
+	inFlight.Add(ctx, 2)
+	temperature.Record(ctx, 21.5)
+	latency.Record(ctx, 0.2)
+	latency.Record(ctx, 0.4)
+
+	if err := reader.ForceFlush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	lines := statsdLines(read())
+
+	expected := []string{
+		"app.requests_total:3|c|#method:GET,status:200",
+		"app.in_flight:2|g",
+		"app.temperature:21.5|g",
+		// Duas amostras no bucket (0.1, 1], entre o mínimo e o máximo observados
+		"app.latency:300|ms|@0.5",
+	}
+	for _, want := range expected {
+		if !slices.Contains(lines, want) {
+			t.Errorf("linha %q não encontrada em %v", want, lines)
+		}
+	}
+
+	// Contadores são deltas: sem novas medições, nada é enviado
+	if err := reader.ForceFlush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range statsdLines(read()) {
+		if strings.HasPrefix(line, "app.requests_total") {
+			t.Errorf("contador sem medições não deveria ser enviado: %q", line)
+		}
+	}
+}
+
+func TestStatsDExporter_PlainStatsDDropsTags(t *testing.T) {
+	addr, read := listenStatsD(t)
+	reader := newStatsDReader(t, StatsDConfig{Endpoint: addr, Flavor: StatsDFlavorStatsD})
+	helper, _ := newTestMetricsHelper(t, metric.WithReader(reader))
+
+	counter, _ := helper.NewCounter("jobs_done", "Jobs")
+	counter.Increment(context.Background(), attribute.String("queue", "emails"))
+	reader.ForceFlush(context.Background())
+
+	if lines := statsdLines(read()); !slices.Contains(lines, "jobs_done:1|c") {
+		t.Errorf("esperado jobs_done:1|c sem tags, obtido %v", lines)
+	}
+}
+
+func TestStatsDExporter_PlainStatsDNegativeGauge(t *testing.T) {
+	addr, read := listenStatsD(t)
+	reader := newStatsDReader(t, StatsDConfig{Endpoint: addr, Flavor: StatsDFlavorStatsD})
+	helper, _ := newTestMetricsHelper(t, metric.WithReader(reader))
+
+	temperature, _ := helper.NewFloat64Gauge("temperature", "Temperatura")
+	temperature.Record(context.Background(), -4.5)
+	reader.ForceFlush(context.Background())
+
+	lines := statsdLines(read())
+	i := slices.Index(lines, "temperature:-4.5|g")
+	if i < 1 || lines[i-1] != "temperature:0|g" {
+		t.Errorf("esperado temperature:0|g antes de temperature:-4.5|g, obtido %v", lines)
+	}
+}
+
+func TestStatsDExporter_MaxPacketSize(t *testing.T) {
+	addr, read := listenStatsD(t)
+	reader := newStatsDReader(t, StatsDConfig{Endpoint: addr, MaxPacketSize: 64})
+	helper, _ := newTestMetricsHelper(t, metric.WithReader(reader))
+
+	counter, _ := helper.NewCounter("events_total", "Eventos")
+	for _, queue := range []string{"a", "b", "c", "d", "e", "f"} {
+		counter.Increment(context.Background(), attribute.String("queue", queue))
+	}
+	reader.ForceFlush(context.Background())
+
+	packets := read()
+	if len(packets) < 2 {
+		t.Errorf("esperados vários datagramas, obtidos %d", len(packets))
+	}
+	for _, p := range packets {
+		if len(p) > 64 {
+			t.Errorf("datagrama com %d bytes excede o limite: %q", len(p), p)
+		}
+	}
+	if n := len(statsdLines(packets)); n != 6 {
+		t.Errorf("esperadas 6 linhas, obtidas %d", n)
+	}
+}
+
+func TestStatsDPackets(t *testing.T) {
+	packets := statsdPackets([]string{"a:1|c", "b:1|c", "muito_longo:1|c", "c:1|c"}, 12)
+
+	var got []string
+	for _, p := range packets {
+		got = append(got, string(p))
+	}
+	want := []string{"a:1|c\nb:1|c", "muito_longo:1|c", "c:1|c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("statsdPackets() = %q, esperado %q", got, want)
+	}
+}
+
+func TestBucketValue(t *testing.T) {
+	bounds := []float64{1, 10}
+	tests := []struct {
+		name         string
+		bucket       int
+		lo, hi       float64
+		hasLo, hasHi bool
+		want         float64
+	}{
+		{"ponto médio", 1, 0, 0, false, false, 5.5},
+		{"primeiro bucket", 0, 0, 0, false, false, 1},
+		{"último bucket", 2, 0, 0, false, false, 10},
+		{"limitado pelo mínimo e máximo", 1, 2, 4, true, true, 3},
+		{"último bucket com máximo", 2, 0, 30, false, true, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bucketValue(bounds, tt.bucket, tt.lo, tt.hasLo, tt.hi, tt.hasHi); got != tt.want {
+				t.Errorf("bucketValue() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_StatsD(t *testing.T) {
+	t.Setenv("GRAFTEL_STATSD_ENDPOINT", "localhost:8125")
+	t.Setenv("GRAFTEL_STATSD_FLAVOR", "statsd")
+	t.Setenv("GRAFTEL_STATSD_PREFIX", "svc.")
+
+	config := NewConfig("test")
+	if config.StatsD.Endpoint != "localhost:8125" || config.StatsD.Flavor != StatsDFlavorStatsD || config.StatsD.Prefix != "svc." {
+		t.Errorf("StatsD via ENV = %+v", config.StatsD)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if config.StatsD.MaxPacketSize != DefaultStatsDMaxPacketSize {
+		t.Errorf("MaxPacketSize = %d, esperado %d", config.StatsD.MaxPacketSize, DefaultStatsDMaxPacketSize)
+	}
+
+	withPrometheus := config.WithPrometheusEndpoint(":8080")
+	var invalid *ErrInvalidConfig
+	if err := withPrometheus.Validate(); !errors.As(err, &invalid) || invalid.Field != "StatsD.Endpoint" {
+		t.Errorf("esperado erro ao combinar StatsD e Prometheus, obtido %v", err)
+	}
+
+	negative := NewConfig("test").WithStatsD(StatsDConfig{Endpoint: "localhost:8125", MaxPacketSize: -1})
+	if err := negative.Validate(); !errors.As(err, &invalid) || invalid.Field != "StatsD.MaxPacketSize" {
+		t.Errorf("esperado erro de MaxPacketSize negativo, obtido %v", err)
+	}
+}
+
+func TestClient_StatsDExport(t *testing.T) {
+	addr, read := listenStatsD(t)
+	client, err := NewClient(NewConfig("test-service").WithInsecure(true).WithStatsD(StatsDConfig{Endpoint: addr}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := client.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	counter, _ := client.NewMetricsHelper("jobs").NewCounter("jobs_total", "Jobs")
+	counter.Increment(ctx)
+	client.Shutdown(ctx)
+
+	if lines := statsdLines(read()); !slices.Contains(lines, "jobs_total:1|c") {
+		t.Errorf("esperado jobs_total:1|c após o Shutdown, obtido %v", lines)
+	}
+}
@@ -0,0 +1,86 @@
+package graftel
+
+import (
+	"fmt"
+	"strings"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// MetricTemporality é a preferência de temporalidade de agregação das métricas exportadas via OTLP.
+// O exporter Prometheus sempre usa temporalidade cumulativa.
+type MetricTemporality int
+
+const (
+	// TemporalityCumulative exporta todos os instrumentos com temporalidade cumulativa.
+	// É o padrão e o formato esperado pelo Prometheus.
+	TemporalityCumulative MetricTemporality = iota
+	// TemporalityDelta exporta Counter, Histogram e ObservableCounter com temporalidade delta;
+	// UpDownCounters permanecem cumulativos.
+	TemporalityDelta
+	// TemporalityLowMemory exporta apenas Counter e Histogram síncronos com temporalidade delta,
+	// reduzindo a memória usada pelo SDK.
+	TemporalityLowMemory
+)
+
+// String retorna a representação em string da temporalidade, no formato de
+// OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE.
+func (t MetricTemporality) String() string {
+	switch t {
+	case TemporalityCumulative:
+		return "cumulative"
+	case TemporalityDelta:
+		return "delta"
+	case TemporalityLowMemory:
+		return "lowmemory"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseMetricTemporality converte "cumulative", "delta" ou "lowmemory" (sem diferenciar
+// maiúsculas) em MetricTemporality.
+func ParseMetricTemporality(s string) (MetricTemporality, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "cumulative":
+		return TemporalityCumulative, nil
+	case "delta":
+		return TemporalityDelta, nil
+	case "lowmemory":
+		return TemporalityLowMemory, nil
+	default:
+		return TemporalityCumulative, fmt.Errorf("temporalidade inválida: %q", s)
+	}
+}
+
+// temporalityFor retorna a temporalidade de um tipo de instrumento segundo a preferência.
+func (t MetricTemporality) temporalityFor(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch t {
+	case TemporalityDelta:
+		switch kind {
+		case sdkmetric.InstrumentKindCounter,
+			sdkmetric.InstrumentKindHistogram,
+			sdkmetric.InstrumentKindObservableCounter:
+			return metricdata.DeltaTemporality
+		}
+	case TemporalityLowMemory:
+		switch kind {
+		case sdkmetric.InstrumentKindCounter,
+			sdkmetric.InstrumentKindHistogram:
+			return metricdata.DeltaTemporality
+		}
+	}
+	return metricdata.CumulativeTemporality
+}
+
+// temporalitySelector cria o seletor usado pelo exporter OTLP a partir da preferência
+// e das sobrescritas por tipo de instrumento.
+func temporalitySelector(preference MetricTemporality, overrides map[sdkmetric.InstrumentKind]metricdata.Temporality) sdkmetric.TemporalitySelector {
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		if temporality, ok := overrides[kind]; ok {
+			return temporality
+		}
+		return preference.temporalityFor(kind)
+	}
+}
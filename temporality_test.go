@@ -0,0 +1,115 @@
+package graftel
+
+import (
+	"os"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestParseMetricTemporality(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    MetricTemporality
+		wantErr bool
+	}{
+		{"cumulative", TemporalityCumulative, false},
+		{"Delta", TemporalityDelta, false},
+		{" lowmemory ", TemporalityLowMemory, false},
+		{"rate", TemporalityCumulative, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMetricTemporality(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMetricTemporality(%q) erro = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseMetricTemporality(%q) = %v, esperado %v", tt.input, got, tt.want)
+		}
+		if !tt.wantErr && got.String() != tt.want.String() {
+			t.Errorf("String() = %q", got.String())
+		}
+	}
+}
+
+func TestTemporalitySelector(t *testing.T) {
+	delta := metricdata.DeltaTemporality
+	cumulative := metricdata.CumulativeTemporality
+
+	tests := []struct {
+		preference MetricTemporality
+		want       map[sdkmetric.InstrumentKind]metricdata.Temporality
+	}{
+		{TemporalityCumulative, map[sdkmetric.InstrumentKind]metricdata.Temporality{
+			sdkmetric.InstrumentKindCounter:                 cumulative,
+			sdkmetric.InstrumentKindHistogram:               cumulative,
+			sdkmetric.InstrumentKindObservableCounter:       cumulative,
+			sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+			sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+		}},
+		{TemporalityDelta, map[sdkmetric.InstrumentKind]metricdata.Temporality{
+			sdkmetric.InstrumentKindCounter:                 delta,
+			sdkmetric.InstrumentKindHistogram:               delta,
+			sdkmetric.InstrumentKindObservableCounter:       delta,
+			sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+			sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+		}},
+		{TemporalityLowMemory, map[sdkmetric.InstrumentKind]metricdata.Temporality{
+			sdkmetric.InstrumentKindCounter:                 delta,
+			sdkmetric.InstrumentKindHistogram:               delta,
+			sdkmetric.InstrumentKindObservableCounter:       cumulative,
+			sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+			sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+		}},
+	}
+
+	for _, tt := range tests {
+		selector := temporalitySelector(tt.preference, nil)
+		for kind, want := range tt.want {
+			if got := selector(kind); got != want {
+				t.Errorf("%s: kind %v = %v, esperado %v", tt.preference, kind, got, want)
+			}
+		}
+	}
+
+	overrides := map[sdkmetric.InstrumentKind]metricdata.Temporality{
+		sdkmetric.InstrumentKindHistogram: cumulative,
+	}
+	selector := temporalitySelector(TemporalityDelta, overrides)
+	if selector(sdkmetric.InstrumentKindHistogram) != cumulative {
+		t.Error("sobrescrita por tipo de instrumento não aplicada")
+	}
+	if selector(sdkmetric.InstrumentKindCounter) != delta {
+		t.Error("preferência global deveria valer para tipos sem sobrescrita")
+	}
+}
+
+func TestConfig_MetricTemporality(t *testing.T) {
+	os.Setenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE", "delta")
+	defer os.Unsetenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE")
+
+	config := NewConfig("test-service")
+	if config.MetricTemporality != TemporalityDelta {
+		t.Errorf("MetricTemporality = %v, esperado delta", config.MetricTemporality)
+	}
+
+	config = config.
+		WithMetricTemporality(TemporalityLowMemory).
+		WithMetricTemporalityOverride(sdkmetric.InstrumentKindObservableCounter, metricdata.DeltaTemporality)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() erro = %v", err)
+	}
+	if config.MetricTemporality != TemporalityLowMemory {
+		t.Errorf("MetricTemporality = %v, esperado lowmemory", config.MetricTemporality)
+	}
+	if config.MetricTemporalityOverrides[sdkmetric.InstrumentKindObservableCounter] != metricdata.DeltaTemporality {
+		t.Error("sobrescrita não registrada")
+	}
+
+	invalid := config.WithMetricTemporality(MetricTemporality(42))
+	if err := invalid.Validate(); err == nil {
+		t.Error("esperado erro para temporalidade desconhecida")
+	}
+}
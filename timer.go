@@ -0,0 +1,108 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrorTypeKey é o atributo registrado por Measure quando a função retorna erro.
+const ErrorTypeKey = attribute.Key("error.type")
+
+// ErrorTypeOther é o valor de error.type usado pela convenção semântica quando o erro
+// não tem um tipo conhecido.
+const ErrorTypeOther = "_OTHER"
+
+// ErrorTyper pode ser implementado por erros para definir o valor de error.type
+// registrado por Measure. O valor deve ter baixa cardinalidade (ex: "timeout", "not_found").
+type ErrorTyper interface {
+	ErrorType() string
+}
+
+// DurationUnit é a unidade em que um histograma registra durações.
+type DurationUnit string
+
+const (
+	// DurationSeconds registra durações em segundos. É o padrão.
+	DurationSeconds DurationUnit = "s"
+	// DurationMilliseconds registra durações em milissegundos.
+	DurationMilliseconds DurationUnit = "ms"
+)
+
+// durationUnitOf converte a unidade configurada no instrumento em DurationUnit.
+// Qualquer unidade diferente de "ms" é tratada como segundos.
+func durationUnitOf(unit string) DurationUnit {
+	if unit == string(DurationMilliseconds) {
+		return DurationMilliseconds
+	}
+	return DurationSeconds
+}
+
+// perSecond retorna quantas unidades cabem em um segundo.
+func (u DurationUnit) perSecond() float64 {
+	if u == DurationMilliseconds {
+		return 1000
+	}
+	return 1
+}
+
+// convert converte a duração para a unidade.
+func (u DurationUnit) convert(d time.Duration) float64 {
+	if u == DurationMilliseconds {
+		return float64(d) / float64(time.Millisecond)
+	}
+	return d.Seconds()
+}
+
+// StartTimer inicia a medição de uma duração e retorna a função que a encerra.
+// A função de parada registra a duração com attrs mais os atributos informados na
+// parada (ex: status, classe do erro) e retorna a duração medida.
+//
+//	stop := histogram.StartTimer(ctx, attribute.String("route", "/users"))
+//	defer stop(attribute.Int("status", status))
+func (h *Histogram) StartTimer(ctx context.Context, attrs ...attribute.KeyValue) func(extra ...attribute.KeyValue) time.Duration {
+	start := time.Now()
+	return func(extra ...attribute.KeyValue) time.Duration {
+		duration := time.Since(start)
+		all := attrs
+		if len(extra) > 0 {
+			all = make([]attribute.KeyValue, 0, len(attrs)+len(extra))
+			all = append(append(all, attrs...), extra...)
+		}
+		h.RecordDuration(ctx, duration, all...)
+		return duration
+	}
+}
+
+// Measure executa fn, registra sua duração e retorna o erro de fn.
+// Quando fn falha, a medição recebe o atributo error.type: o valor de ErrorTyper se algum
+// erro da cadeia o implementar, context_canceled ou context_deadline_exceeded para erros
+// de contexto e ErrorTypeOther nos demais casos.
+func (h *Histogram) Measure(ctx context.Context, fn func(context.Context) error, attrs ...attribute.KeyValue) error {
+	stop := h.StartTimer(ctx, attrs...)
+	err := fn(ctx)
+	if err != nil {
+		stop(ErrorTypeKey.String(errorType(err)))
+	} else {
+		stop()
+	}
+	return err
+}
+
+// errorType retorna um identificador de baixa cardinalidade para o erro.
+func errorType(err error) string {
+	var typer ErrorTyper
+	switch {
+	case errors.As(err, &typer):
+		if t := typer.ErrorType(); t != "" {
+			return t
+		}
+	case errors.Is(err, context.Canceled):
+		return "context_canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "context_deadline_exceeded"
+	}
+	return ErrorTypeOther
+}
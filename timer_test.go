@@ -0,0 +1,135 @@
+package graftel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHistogram_StartTimer(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	histogram, err := helper.NewHistogram("request_duration_seconds", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := histogram.StartTimer(context.Background(), attribute.String("route", "/users"))
+	time.Sleep(5 * time.Millisecond)
+	elapsed := stop(attribute.Int("status", 200))
+
+	if elapsed < 5*time.Millisecond {
+		t.Errorf("duração = %v, esperado >= 5ms", elapsed)
+	}
+
+	dp := collectMetrics(t, reader)["request_duration_seconds"].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if dp.Sum != elapsed.Seconds() {
+		t.Errorf("soma = %v, esperado %v", dp.Sum, elapsed.Seconds())
+	}
+	if _, ok := dp.Attributes.Value("route"); !ok {
+		t.Error("atributo de início não registrado")
+	}
+	if status, _ := dp.Attributes.Value("status"); status.AsInt64() != 200 {
+		t.Error("atributo de parada não registrado")
+	}
+}
+
+func TestHistogram_Measure(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	histogram, err := helper.NewHistogram("job_duration_seconds", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := histogram.Measure(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("Measure() erro = %v", err)
+	}
+
+	wantErr := &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}
+	if err := histogram.Measure(ctx, func(context.Context) error { return wantErr }); err != wantErr {
+		t.Fatalf("Measure() deve retornar o erro de fn, obtido %v", err)
+	}
+
+	points := collectMetrics(t, reader)["job_duration_seconds"].Data.(metricdata.Histogram[float64]).DataPoints
+	if len(points) != 2 {
+		t.Fatalf("esperado 2 séries (sucesso e erro), obtido %d", len(points))
+	}
+	var found bool
+	for _, dp := range points {
+		if v, ok := dp.Attributes.Value(ErrorTypeKey); ok {
+			found = true
+			if v.AsString() != ErrorTypeOther {
+				t.Errorf("error.type = %q, esperado %s", v.AsString(), ErrorTypeOther)
+			}
+		}
+	}
+	if !found {
+		t.Error("atributo error.type não registrado na falha")
+	}
+}
+
+// typedError é um erro que define o próprio error.type.
+type typedError string
+
+func (e typedError) Error() string     { return "falha: " + string(e) }
+func (e typedError) ErrorType() string { return string(e) }
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{context.Canceled, "context_canceled"},
+		{fmt.Errorf("consulta: %w", context.DeadlineExceeded), "context_deadline_exceeded"},
+		{errors.New("falha"), ErrorTypeOther},
+		{fmt.Errorf("salvar: %w", typedError("not_found")), "not_found"},
+		{typedError(""), ErrorTypeOther},
+	}
+	for _, tt := range tests {
+		if got := errorType(tt.err); got != tt.want {
+			t.Errorf("errorType(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNewDurationHistogram(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	histogram, err := NewDurationHistogram(helper, "db_query_duration", "test", DurationMilliseconds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram.RecordDuration(context.Background(), 250*time.Millisecond)
+
+	m := collectMetrics(t, reader)["db_query_duration"]
+	if m.Unit != "ms" {
+		t.Errorf("unidade = %q, esperado ms", m.Unit)
+	}
+	dp := m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if dp.Sum != 250 {
+		t.Errorf("soma = %v, esperado 250", dp.Sum)
+	}
+	if dp.Bounds[0] != DurationBuckets[0]*1000 {
+		t.Errorf("buckets não convertidos para ms: %v", dp.Bounds)
+	}
+}
+
+func TestHistogram_RecordDurationHonorsUnit(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t)
+	histogram, err := helper.NewHistogram("latency_ms", "test", otelmetric.WithUnit("ms"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram.RecordDuration(context.Background(), 1500*time.Microsecond)
+
+	dp := collectMetrics(t, reader)["latency_ms"].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if dp.Sum != 1.5 {
+		t.Errorf("soma = %v, esperado 1.5ms", dp.Sum)
+	}
+}
@@ -0,0 +1,294 @@
+package graftel
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// DurationBuckets são limites de histograma adequados para latências em segundos,
+// com resolução maior abaixo de 1s, onde a maioria das requisições HTTP se concentra.
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// MetricView customiza como os instrumentos são agregados e exportados.
+// Views são aplicadas pelo MeterProvider criado em Initialize.
+type MetricView struct {
+	// InstrumentName é o nome do instrumento ou um padrão com * e ? (ex: "http_*").
+	InstrumentName string
+
+	// Rename é o novo nome do instrumento. Só pode ser usado quando
+	// InstrumentName não é um padrão.
+	Rename string
+
+	// Buckets são os limites explícitos do histograma, em ordem crescente.
+	Buckets []float64
+
+	// ExponentialHistogram usa um histograma exponencial base-2 em vez de limites explícitos.
+	ExponentialHistogram bool
+
+	// ExponentialMaxSize é o número máximo de buckets do histograma exponencial.
+	// Padrão: 160
+	ExponentialMaxSize int32
+
+	// ExponentialMaxScale é a escala (resolução) máxima do histograma exponencial.
+	// Padrão: 20
+	ExponentialMaxScale int32
+
+	// AllowedAttributes mantém apenas as chaves de atributo listadas.
+	AllowedAttributes []string
+
+	// DroppedAttributes remove as chaves de atributo listadas.
+	DroppedAttributes []string
+}
+
+// isPattern indica se InstrumentName contém curingas.
+func (v MetricView) isPattern() bool {
+	return strings.ContainsAny(v.InstrumentName, "*?")
+}
+
+// validate verifica se a view pode ser aplicada pelo SDK.
+func (v MetricView) validate() error {
+	if v.InstrumentName == "" {
+		return &ErrInvalidConfig{Field: "MetricViews", Message: "InstrumentName é obrigatório"}
+	}
+
+	if v.Rename != "" && v.isPattern() {
+		return &ErrInvalidConfig{
+			Field:   "MetricViews",
+			Message: fmt.Sprintf("não é possível renomear o padrão '%s'", v.InstrumentName),
+		}
+	}
+
+	if len(v.Buckets) > 0 && v.ExponentialHistogram {
+		return &ErrInvalidConfig{
+			Field:   "MetricViews",
+			Message: fmt.Sprintf("'%s': Buckets e ExponentialHistogram são mutuamente exclusivos", v.InstrumentName),
+		}
+	}
+
+	for i := 1; i < len(v.Buckets); i++ {
+		if v.Buckets[i] <= v.Buckets[i-1] {
+			return &ErrInvalidConfig{
+				Field:   "MetricViews",
+				Message: fmt.Sprintf("'%s': Buckets devem estar em ordem crescente", v.InstrumentName),
+			}
+		}
+	}
+
+	if v.ExponentialMaxScale < -10 || v.ExponentialMaxScale > 20 {
+		return &ErrInvalidConfig{
+			Field:   "MetricViews",
+			Message: fmt.Sprintf("'%s': ExponentialMaxScale deve estar entre -10 e 20", v.InstrumentName),
+		}
+	}
+
+	if v.ExponentialMaxSize < 0 {
+		return &ErrInvalidConfig{
+			Field:   "MetricViews",
+			Message: fmt.Sprintf("'%s': ExponentialMaxSize não pode ser negativo", v.InstrumentName),
+		}
+	}
+
+	return nil
+}
+
+// sdkView converte a view para o formato do SDK, para instrumentos do tipo kind.
+// Buckets e histogramas exponenciais só se aplicam a histogramas: um padrão como
+// "http_*" não deve transformar contadores em histogramas.
+func (v MetricView) sdkView(kind sdkmetric.InstrumentKind) sdkmetric.View {
+	stream := sdkmetric.Stream{
+		Name: v.Rename,
+	}
+
+	switch {
+	case kind != sdkmetric.InstrumentKindHistogram:
+	case len(v.Buckets) > 0:
+		stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: v.Buckets,
+		}
+	case v.ExponentialHistogram:
+		maxSize := v.ExponentialMaxSize
+		if maxSize == 0 {
+			maxSize = 160
+		}
+		maxScale := v.ExponentialMaxScale
+		if maxScale == 0 {
+			maxScale = 20
+		}
+		stream.Aggregation = sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  maxSize,
+			MaxScale: maxScale,
+		}
+	}
+
+	stream.AttributeFilter = attributeFilter(v.AllowedAttributes, v.DroppedAttributes)
+
+	return sdkmetric.NewView(sdkmetric.Instrument{Name: v.InstrumentName, Kind: kind}, stream)
+}
+
+// merge sobrepõe os campos definidos em other. Atributos removidos são acumulados.
+func (v MetricView) merge(other MetricView) MetricView {
+	if other.Rename != "" {
+		v.Rename = other.Rename
+	}
+	if len(other.Buckets) > 0 {
+		v.Buckets = other.Buckets
+		v.ExponentialHistogram = false
+	}
+	if other.ExponentialHistogram {
+		v.ExponentialHistogram = true
+		v.ExponentialMaxSize = other.ExponentialMaxSize
+		v.ExponentialMaxScale = other.ExponentialMaxScale
+		v.Buckets = nil
+	}
+	if len(other.AllowedAttributes) > 0 {
+		v.AllowedAttributes = other.AllowedAttributes
+	}
+	if len(other.DroppedAttributes) > 0 {
+		v.DroppedAttributes = append(append([]string(nil), v.DroppedAttributes...), other.DroppedAttributes...)
+	}
+	return v
+}
+
+// attributeFilter combina uma allowlist e uma denylist de chaves.
+// Retorna nil quando nenhuma das duas foi configurada.
+func attributeFilter(allowed, dropped []string) attribute.Filter {
+	var allow, deny attribute.Filter
+	if len(allowed) > 0 {
+		allow = attribute.NewAllowKeysFilter(toAttributeKeys(allowed)...)
+	}
+	if len(dropped) > 0 {
+		deny = attribute.NewDenyKeysFilter(toAttributeKeys(dropped)...)
+	}
+
+	switch {
+	case allow != nil && deny != nil:
+		return func(kv attribute.KeyValue) bool {
+			return allow(kv) && deny(kv)
+		}
+	case allow != nil:
+		return allow
+	default:
+		return deny
+	}
+}
+
+func toAttributeKeys(keys []string) []attribute.Key {
+	out := make([]attribute.Key, len(keys))
+	for i, k := range keys {
+		out[i] = attribute.Key(k)
+	}
+	return out
+}
+
+// sdkViews converte as views configuradas em uma única view do SDK. Cada view que
+// corresponde a um instrumento geraria um stream duplicado, então as views que
+// correspondem ao mesmo instrumento são mescladas: padrões primeiro e nomes exatos por
+// último, prevalecendo sobre os padrões.
+func sdkViews(views []MetricView) []sdkmetric.View {
+	if len(views) == 0 {
+		return nil
+	}
+
+	ordered := make([]MetricView, 0, len(views))
+	for _, v := range views {
+		if v.isPattern() {
+			ordered = append(ordered, v)
+		}
+	}
+	for _, v := range views {
+		if !v.isPattern() {
+			ordered = append(ordered, v)
+		}
+	}
+
+	matchers := make([]sdkmetric.View, len(ordered))
+	for i, v := range ordered {
+		matchers[i] = sdkmetric.NewView(sdkmetric.Instrument{Name: v.InstrumentName}, sdkmetric.Stream{})
+	}
+
+	return []sdkmetric.View{func(inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		var merged MetricView
+		matched := false
+		for i, v := range ordered {
+			if _, ok := matchers[i](inst); ok {
+				merged = merged.merge(v)
+				matched = true
+			}
+		}
+		if !matched {
+			return sdkmetric.Stream{}, false
+		}
+		merged.InstrumentName = inst.Name
+		return merged.sdkView(inst.Kind)(inst)
+	}}
+}
+
+// withView aplica fn à view de InstrumentName, criando-a se necessário. Views de
+// nomes diferentes que correspondem ao mesmo instrumento são mescladas por sdkViews.
+func (c Config) withView(instrumentName string, fn func(*MetricView)) Config {
+	views := make([]MetricView, len(c.MetricViews), len(c.MetricViews)+1)
+	copy(views, c.MetricViews)
+
+	for i := range views {
+		if views[i].InstrumentName == instrumentName {
+			fn(&views[i])
+			c.MetricViews = views
+			return c
+		}
+	}
+
+	view := MetricView{InstrumentName: instrumentName}
+	fn(&view)
+	c.MetricViews = append(views, view)
+	return c
+}
+
+// WithMetricView adiciona uma view de métricas.
+func (c Config) WithMetricView(view MetricView) Config {
+	c.MetricViews = append(append([]MetricView(nil), c.MetricViews...), view)
+	return c
+}
+
+// WithHistogramBuckets define limites explícitos para os histogramas cujo nome
+// corresponde a instrumentName (aceita * e ?).
+func (c Config) WithHistogramBuckets(instrumentName string, buckets ...float64) Config {
+	return c.withView(instrumentName, func(v *MetricView) {
+		v.Buckets = buckets
+		v.ExponentialHistogram = false
+	})
+}
+
+// WithExponentialHistogram usa histogramas exponenciais base-2 para os instrumentos
+// cujo nome corresponde a instrumentName (aceita * e ?).
+func (c Config) WithExponentialHistogram(instrumentName string) Config {
+	return c.withView(instrumentName, func(v *MetricView) {
+		v.ExponentialHistogram = true
+		v.Buckets = nil
+	})
+}
+
+// WithMetricAttributes mantém apenas as chaves de atributo listadas nos instrumentos
+// cujo nome corresponde a instrumentName (aceita * e ?).
+func (c Config) WithMetricAttributes(instrumentName string, keys ...string) Config {
+	return c.withView(instrumentName, func(v *MetricView) {
+		v.AllowedAttributes = keys
+	})
+}
+
+// WithoutMetricAttributes remove as chaves de atributo listadas dos instrumentos
+// cujo nome corresponde a instrumentName (aceita * e ?).
+func (c Config) WithoutMetricAttributes(instrumentName string, keys ...string) Config {
+	return c.withView(instrumentName, func(v *MetricView) {
+		v.DroppedAttributes = keys
+	})
+}
+
+// WithMetricRename renomeia o instrumento instrumentName para newName.
+func (c Config) WithMetricRename(instrumentName, newName string) Config {
+	return c.withView(instrumentName, func(v *MetricView) {
+		v.Rename = newName
+	})
+}
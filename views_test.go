@@ -0,0 +1,226 @@
+package graftel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// collectMetrics coleta as métricas do reader e as indexa por nome.
+func collectMetrics(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() erro = %v", err)
+	}
+	out := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			out[m.Name] = m
+		}
+	}
+	return out
+}
+
+// newTestMetricsHelper cria um InstrumentsHelper sobre um MeterProvider com um ManualReader,
+// para ler as métricas com collectMetrics. opts acrescenta views, filtros de exemplars ou
+// outros readers ao provider.
+func newTestMetricsHelper(t testing.TB, opts ...metric.Option) (InstrumentsHelper, *metric.ManualReader) {
+	t.Helper()
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(append([]metric.Option{
+		metric.WithReader(reader),
+		metric.WithResource(resource.Empty()),
+	}, opts...)...)
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return NewMetricsHelper(provider.Meter("test")).(InstrumentsHelper), reader
+}
+
+func TestMetricView_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    MetricView
+		wantErr bool
+	}{
+		{"buckets", MetricView{InstrumentName: "latency", Buckets: []float64{0.1, 0.5, 1}}, false},
+		{"padrão com filtro", MetricView{InstrumentName: "http_*", DroppedAttributes: []string{"path"}}, false},
+		{"sem nome", MetricView{Buckets: []float64{1}}, true},
+		{"renomear padrão", MetricView{InstrumentName: "http_*", Rename: "x"}, true},
+		{"buckets fora de ordem", MetricView{InstrumentName: "latency", Buckets: []float64{1, 0.5}}, true},
+		{"buckets e exponencial", MetricView{InstrumentName: "latency", Buckets: []float64{1}, ExponentialHistogram: true}, true},
+		{"escala inválida", MetricView{InstrumentName: "latency", ExponentialHistogram: true, ExponentialMaxScale: 21}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.view.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() erro = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ViewBuildersMergeByInstrument(t *testing.T) {
+	config := NewConfig("test-service").
+		WithHistogramBuckets("http_request_duration_seconds", 0.1, 0.5, 1).
+		WithoutMetricAttributes("http_request_duration_seconds", "path").
+		WithExponentialHistogram("rpc_*")
+
+	if len(config.MetricViews) != 2 {
+		t.Fatalf("esperado 2 views, obtido %d", len(config.MetricViews))
+	}
+
+	view := config.MetricViews[0]
+	if len(view.Buckets) != 3 || len(view.DroppedAttributes) != 1 {
+		t.Errorf("view não mesclada corretamente: %+v", view)
+	}
+	if !config.MetricViews[1].ExponentialHistogram {
+		t.Error("esperado histograma exponencial para rpc_*")
+	}
+
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() erro = %v", err)
+	}
+
+	invalid := config.WithMetricRename("rpc_*", "rpc")
+	if err := invalid.Validate(); err == nil {
+		t.Error("esperado erro ao renomear um padrão")
+	}
+	if config.MetricViews[1].Rename != "" {
+		t.Error("With* não deve alterar a configuração original")
+	}
+}
+
+func TestMetricView_ExplicitBuckets(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithView(sdkViews([]MetricView{{
+		InstrumentName: "http_*",
+		Buckets:        []float64{0.1, 0.5, 1},
+	}})...))
+
+	histogram, err := helper.NewHistogram("http_request_duration_seconds", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram.Record(context.Background(), 0.3)
+
+	m := collectMetrics(t, reader)["http_request_duration_seconds"]
+	data, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("tipo de dado inesperado: %T", m.Data)
+	}
+	bounds := data.DataPoints[0].Bounds
+	if len(bounds) != 3 || bounds[0] != 0.1 || bounds[2] != 1 {
+		t.Errorf("limites inesperados: %v", bounds)
+	}
+	if data.DataPoints[0].BucketCounts[1] != 1 {
+		t.Errorf("esperado valor no bucket (0.1, 0.5], obtido %v", data.DataPoints[0].BucketCounts)
+	}
+}
+
+func TestMetricView_ExponentialHistogram(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithView(sdkViews([]MetricView{{
+		InstrumentName:       "latency",
+		ExponentialHistogram: true,
+	}})...))
+
+	histogram, err := helper.NewHistogram("latency", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram.Record(context.Background(), 0.3)
+
+	m := collectMetrics(t, reader)["latency"]
+	if _, ok := m.Data.(metricdata.ExponentialHistogram[float64]); !ok {
+		t.Fatalf("esperado histograma exponencial, obtido %T", m.Data)
+	}
+}
+
+func TestMetricView_AttributesAndRename(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithView(sdkViews([]MetricView{
+		{InstrumentName: "requests", Rename: "http.server.requests", DroppedAttributes: []string{"path"}},
+		{InstrumentName: "jobs", AllowedAttributes: []string{"queue"}},
+	})...))
+
+	ctx := context.Background()
+	requests, _ := helper.NewCounter("requests", "test")
+	requests.Add(ctx, 1, attribute.String("path", "/a"), attribute.String("method", "GET"))
+	requests.Add(ctx, 1, attribute.String("path", "/b"), attribute.String("method", "GET"))
+
+	jobs, _ := helper.NewCounter("jobs", "test")
+	jobs.Add(ctx, 1, attribute.String("queue", "default"), attribute.String("id", "1"))
+
+	metrics := collectMetrics(t, reader)
+	if _, ok := metrics["requests"]; ok {
+		t.Error("instrumento deveria ter sido renomeado")
+	}
+
+	sum := metrics["http.server.requests"].Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+		t.Fatalf("esperado um único ponto com valor 2, obtido %+v", sum.DataPoints)
+	}
+	if _, ok := sum.DataPoints[0].Attributes.Value("path"); ok {
+		t.Error("atributo path deveria ter sido removido")
+	}
+
+	jobPoint := metrics["jobs"].Data.(metricdata.Sum[int64]).DataPoints[0]
+	if jobPoint.Attributes.Len() != 1 {
+		t.Errorf("esperado apenas o atributo queue, obtido %v", jobPoint.Attributes.ToSlice())
+	}
+}
+
+func TestMetricView_BucketsOnlyApplyToHistograms(t *testing.T) {
+	helper, reader := newTestMetricsHelper(t, metric.WithView(sdkViews([]MetricView{{
+		InstrumentName: "http_*",
+		Buckets:        []float64{0.1, 0.5, 1},
+	}})...))
+
+	ctx := context.Background()
+	counter, _ := helper.NewCounter("http_requests_total", "test")
+	counter.Add(ctx, 1)
+	if _, err := helper.NewGauge("http_inflight", "test", func(_ context.Context, o otelmetric.Float64Observer) error {
+		o.Observe(3)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := collectMetrics(t, reader)
+	if _, ok := metrics["http_requests_total"].Data.(metricdata.Sum[int64]); !ok {
+		t.Errorf("counter não deveria virar histograma: %T", metrics["http_requests_total"].Data)
+	}
+	if _, ok := metrics["http_inflight"].Data.(metricdata.Gauge[float64]); !ok {
+		t.Errorf("gauge observável deveria ser exportado: %T", metrics["http_inflight"].Data)
+	}
+}
+
+func TestMetricView_OverlappingViewsAreMerged(t *testing.T) {
+	config := NewConfig("test-service").
+		WithHistogramBuckets("http_request_duration_seconds", 0.1, 0.5, 1).
+		WithoutMetricAttributes("http_*", "path")
+	helper, reader := newTestMetricsHelper(t, metric.WithView(sdkViews(config.MetricViews)...))
+
+	histogram, _ := helper.NewHistogram("http_request_duration_seconds", "test")
+	histogram.Record(context.Background(), 0.3, attribute.String("path", "/a"), attribute.String("method", "GET"))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rm.ScopeMetrics[0].Metrics); n != 1 {
+		t.Fatalf("esperado um único stream, obtidos %d", n)
+	}
+
+	data := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	point := data.DataPoints[0]
+	if len(point.Bounds) != 3 {
+		t.Errorf("buckets da view exata deveriam ser aplicados: %v", point.Bounds)
+	}
+	if _, ok := point.Attributes.Value("path"); ok {
+		t.Error("atributo path deveria ser removido pela view do padrão")
+	}
+}